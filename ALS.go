@@ -0,0 +1,380 @@
+package sudoku
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// maxALSSize is the largest number of squares of the almost locked sets the
+// techniques look for.
+const maxALSSize = 5
+
+// als is an almost locked set: n unsolved squares of a unit with n+1
+// candidates between them. Whichever candidate is ruled out, the others are
+// locked in the squares.
+type als struct {
+	squares []int
+	values  uint16
+	set     squareSet
+	// with holds the squares of the set having each value as candidate, and
+	// seenBy the squares seeing all of them.
+	with, seenBy [numDigits]squareSet
+}
+
+func newALS(g *grid, squares []int) *als {
+	a := &als{squares: slices.Clone(squares)}
+	for i := range a.seenBy {
+		a.seenBy[i] = squareSet{^uint64(0), ^uint64(0)}
+	}
+	for _, s := range squares {
+		a.values |= g.candidates[s]
+		a.set.add(s)
+		for _, value := range maskValues(g.candidates[s]) {
+			a.with[value-1].add(s)
+			a.seenBy[value-1] = a.seenBy[value-1].intersect(peerSets[s])
+		}
+	}
+	return a
+}
+
+// String returns the squares of the set, in parentheses.
+func (a *als) String() string {
+	return "(" + squareList(a.squares).String() + ")"
+}
+
+// squareSet is a set of squares.
+type squareSet [2]uint64
+
+func (s *squareSet) add(square int) {
+	s[square/64] |= 1 << (square % 64)
+}
+
+func (s squareSet) has(square int) bool {
+	return s[square/64]&(1<<(square%64)) != 0
+}
+
+func (s squareSet) overlaps(t squareSet) bool {
+	return s[0]&t[0] != 0 || s[1]&t[1] != 0
+}
+
+func (s squareSet) intersect(t squareSet) squareSet {
+	return squareSet{s[0] & t[0], s[1] & t[1]}
+}
+
+func (s squareSet) union(t squareSet) squareSet {
+	return squareSet{s[0] | t[0], s[1] | t[1]}
+}
+
+// subsetOf reports whether every square of s is in t.
+func (s squareSet) subsetOf(t squareSet) bool {
+	return s[0]&^t[0] == 0 && s[1]&^t[1] == 0
+}
+
+// squares returns the squares of the set, in increasing order.
+func (s squareSet) squares() []int {
+	var squares []int
+	for i, word := range s {
+		for ; word != 0; word &= word - 1 {
+			squares = append(squares, i*64+bits.TrailingZeros64(word))
+		}
+	}
+	return squares
+}
+
+// alsList returns the almost locked sets of the grid, each once.
+func (g *grid) alsList() []*als {
+	var list []*als
+	seen := map[squareSet]bool{}
+	for u := range numUnits {
+		var unsolved []int
+		for _, s := range unitSquares[u] {
+			if g.candidates[s] != 0 {
+				unsolved = append(unsolved, s)
+			}
+		}
+
+		for n := 1; n < len(unsolved) && n <= maxALSSize; n++ {
+			combinations(unsolved, n, func(squares []int) bool {
+				if bits.OnesCount16(g.unionOf(squares)) != n+1 {
+					return true
+				}
+				if a := newALS(g, squares); !seen[a.set] {
+					seen[a.set] = true
+					list = append(list, a)
+				}
+				return true
+			})
+		}
+	}
+	return list
+}
+
+// restrictedCommons returns the values common to two sets whose squares in
+// one all see those in the other: at most one of the sets holds them.
+func (g *grid) restrictedCommons(a, b *als) uint16 {
+	var commons uint16
+	for _, value := range maskValues(a.values & b.values) {
+		if b.with[value-1].subsetOf(a.seenBy[value-1]) {
+			commons |= valueBit(value)
+		}
+	}
+	return commons
+}
+
+// alsEliminate adds to the step the candidates z seeing all the candidates z
+// of the sets, which hold z between them.
+func (g *grid) alsEliminate(step *Step, z int, sets ...*als) {
+	seen := squareSet{^uint64(0), ^uint64(0)}
+	for _, a := range sets {
+		seen = seen.intersect(a.seenBy[z-1])
+	}
+	g.eliminate(step, seen.squares(), valueBit(z))
+}
+
+// alsStep returns the step of the sets, if it rules out candidates.
+func alsStep(step *Step, sets ...*als) *Step {
+	var squares []int
+	var values uint16
+	for _, a := range sets {
+		squares = append(squares, a.squares...)
+		values |= a.values
+	}
+	return newStep(step, squares, values)
+}
+
+// alsXZ works on two sets with a restricted common value x: one of them is
+// locked without x, so a value z common to both is in one of them.
+var alsXZ = technique{name: "ALS-XZ", find: func(g *grid) *Step {
+	list := g.alsList()
+	for i, a := range list {
+		for _, b := range list[i+1:] {
+			if a.set.overlaps(b.set) {
+				continue
+			}
+
+			for _, x := range maskValues(g.restrictedCommons(a, b)) {
+				for _, z := range maskValues(a.values & b.values &^ valueBit(x)) {
+					step := &Step{}
+					g.alsEliminate(step, z, a, b)
+					if step := alsStep(step, a, b); step != nil {
+						return step.because("The almost locked sets %v and %v cannot both hold %d: one of them is locked without it and holds %d", a, b, x, z)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// alsXYWing works on three sets, where a pivot set has a restricted common x
+// with a first set and y with a second. One of the pivot's values x and y is
+// ruled out, so the first or second set is locked without x or y: a value z
+// common to both is in one of them.
+var alsXYWing = technique{name: "ALS-XY-Wing", find: func(g *grid) *Step {
+	list := g.alsList()
+	for _, pivot := range list {
+		var wings []*als
+		var rccs []uint16
+		for _, a := range list {
+			if a.set.overlaps(pivot.set) {
+				continue
+			}
+			if x := g.restrictedCommons(pivot, a); x != 0 {
+				wings = append(wings, a)
+				rccs = append(rccs, x)
+			}
+		}
+
+		for i, a := range wings {
+			for j := i + 1; j < len(wings); j++ {
+				b := wings[j]
+				if a.set.overlaps(b.set) {
+					continue
+				}
+
+				for _, x := range maskValues(rccs[i]) {
+					for _, y := range maskValues(rccs[j] &^ valueBit(x)) {
+						for _, z := range maskValues(a.values & b.values &^ (valueBit(x) | valueBit(y))) {
+							step := &Step{}
+							g.alsEliminate(step, z, a, b)
+							if step := alsStep(step, pivot, a, b); step != nil {
+								return step.because("The almost locked set %v holds %d or %d: %v is locked without %d or %v without %d, and one of them holds %d",
+									pivot, x, y, a, x, b, y, z)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// deathBlossom works on a stem square and a set per candidate v of the stem,
+// a petal whose candidates v all see the stem. Whichever value the stem
+// holds, a petal is locked without it, so a value z common to every petal but
+// not to the stem is in one of them.
+var deathBlossom = technique{name: "Death Blossom", find: func(g *grid) *Step {
+	list := g.alsList()
+	for stem, mask := range g.candidates {
+		if n := bits.OnesCount16(mask); n < 2 || n > 3 {
+			continue
+		}
+
+		// The candidate petals of each value of the stem.
+		values := maskValues(mask)
+		petals := make([][]*als, len(values))
+		for i, value := range values {
+			for _, a := range list {
+				if a.values&valueBit(value) != 0 && !a.set.has(stem) && a.seenBy[value-1].has(stem) {
+					petals[i] = append(petals[i], a)
+				}
+			}
+		}
+
+		var found *Step
+		var chosen []*als
+		var pick func(i int, commons uint16, used squareSet) bool
+		pick = func(i int, commons uint16, used squareSet) bool {
+			if i == len(values) {
+				for _, z := range maskValues(commons) {
+					step := &Step{}
+					g.alsEliminate(step, z, chosen...)
+					found = alsStep(step, chosen...)
+					if found != nil {
+						found.Squares = append([]Square{squareAt(stem)}, found.Squares...)
+						found.Values = maskValues(mask | alsValues(chosen))
+						found.because("Whichever of %v the stem %v holds, the petal seeing it among %s, one per value, is locked without it and holds %d",
+							valueMask(mask), squareAt(stem), join(chosen, ", "), z)
+						return false
+					}
+				}
+				return true
+			}
+
+			for _, a := range petals[i] {
+				if a.set.overlaps(used) || a.values&commons == 0 {
+					continue
+				}
+
+				chosen = append(chosen, a)
+				if !pick(i+1, commons&a.values, used.union(a.set)) {
+					return false
+				}
+				chosen = chosen[:len(chosen)-1]
+			}
+			return true
+		}
+		if pick(0, allValues&^mask, squareSet{}); found != nil {
+			return found
+		}
+	}
+	return nil
+}}
+
+func alsValues(sets []*als) uint16 {
+	var values uint16
+	for _, a := range sets {
+		values |= a.values
+	}
+	return values
+}
+
+// sueDeCoq works on two or three unsolved squares at the intersection of a box
+// and a line, with candidates V, and on squares of the rest of the line and
+// of the rest of the box, with candidates VL and VB without common values.
+// When the squares are as many as their candidates, every value is locked:
+// VL and the values of V outside VB are in the line, and VB and the values
+// of V outside VL in the box.
+var sueDeCoq = technique{name: "Sue de Coq", find: func(g *grid) *Step {
+	for box := 2 * numDigits; box < numUnits; box++ {
+		for _, line := range boxLines(box) {
+			var intersection []int
+			for _, s := range unitSquares[line] {
+				if g.candidates[s] != 0 && squareUnits[s][2] == box {
+					intersection = append(intersection, s)
+				}
+			}
+
+			for n := 2; n <= len(intersection); n++ {
+				var found *Step
+				combinations(intersection, n, func(core []int) bool {
+					found = g.sueDeCoqStep(box, line, slices.Clone(core))
+					return found == nil
+				})
+				if found != nil {
+					return found
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+func (g *grid) sueDeCoqStep(box, line int, core []int) *Step {
+	var v uint16
+	for _, s := range core {
+		v |= g.candidates[s]
+	}
+	if bits.OnesCount16(v) < len(core)+2 {
+		return nil
+	}
+
+	lineRest := g.restOf(line, box, v)
+	boxRest := g.restOf(box, line, v)
+	var found *Step
+	for nl := 1; nl <= 2 && found == nil; nl++ {
+		combinations(lineRest, nl, func(lineSquares []int) bool {
+			vl := g.unionOf(lineSquares)
+			for nb := 1; nb <= 2; nb++ {
+				combinations(boxRest, nb, func(boxSquares []int) bool {
+					vb := g.unionOf(boxSquares)
+					if vl&vb != 0 || bits.OnesCount16(v|vl|vb) != len(core)+nl+nb {
+						return true
+					}
+
+					pattern := slices.Concat(core, lineSquares, boxSquares)
+					step := &Step{Units: unitsOf(box, line)}
+					g.eliminate(step, without(unitSquares[line][:], pattern), vl|v&^vb)
+					g.eliminate(step, without(unitSquares[box][:], pattern), vb|v&^vl)
+					found = newStep(step, pattern, v|vl|vb).because("%v, where %v and %v cross, %v, in the rest of %v, and %v, in the rest of %v, have as many values as squares between them: %v are locked in %v and %v in %v",
+						squareList(core), unitAt(box), unitAt(line), squareList(lineSquares), unitAt(line), squareList(boxSquares), unitAt(box),
+						valueMask(vl|v&^vb), unitAt(line), valueMask(vb|v&^vl), unitAt(box))
+					return found == nil
+				})
+				if found != nil {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	return found
+}
+
+// restOf returns the unsolved squares of a unit outside another one, having
+// candidates in common with v.
+func (g *grid) restOf(unit, other int, v uint16) []int {
+	var squares []int
+	for _, s := range unitSquares[unit] {
+		if g.candidates[s]&v != 0 && bits.OnesCount16(g.candidates[s]) >= 2 && !slices.Contains(squareUnits[s][:], other) {
+			squares = append(squares, s)
+		}
+	}
+	return squares
+}
+
+func (g *grid) unionOf(squares []int) uint16 {
+	var values uint16
+	for _, s := range squares {
+		values |= g.candidates[s]
+	}
+	return values
+}
+
+// boxLines returns the rows and columns crossing a box.
+func boxLines(box int) []int {
+	first := unitSquares[box][0]
+	row, column := first/numColumns, first%numColumns
+	return []int{row, row + 1, row + 2, numDigits + column, numDigits + column + 1, numDigits + column + 2}
+}
@@ -0,0 +1,14 @@
+package sudoku_test
+
+import (
+	"testing"
+)
+
+func TestALS(t *testing.T) {
+	boards := stuckBoards(t)
+	for _, name := range []string{"Sue de Coq", "ALS-XZ", "ALS-XY-Wing", "Death Blossom"} {
+		t.Run(name, func(t *testing.T) {
+			assertTechniqueOnStuckBoards(t, name, boards)
+		})
+	}
+}
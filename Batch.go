@@ -0,0 +1,152 @@
+package sudoku
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions configures SolveBatch.
+type BatchOptions struct {
+	// Workers is the number of puzzles solved concurrently. Zero or less
+	// means runtime.GOMAXPROCS(0).
+	Workers int
+	// Ordered makes results come out in the order the puzzles came in,
+	// rather than as soon as they are solved. Workers then stay within
+	// Workers puzzles of the next result to send, so that a slow puzzle does
+	// not leave an unbounded number of results waiting behind it.
+	Ordered bool
+	// Solve bounds the work done on each puzzle.
+	Solve SolveOptions
+}
+
+// Result is the outcome of solving one puzzle of a batch.
+type Result struct {
+	// Index is the position of the puzzle in the input, counting from 0.
+	Index  int
+	Puzzle string
+	// Solution is nil if the puzzle could not be parsed or solved, in which
+	// case Err says why.
+	Solution *Board
+	Stats    SolveStats
+	Err      error
+}
+
+type batchJob struct {
+	index  int
+	puzzle string
+}
+
+// SolveBatch parses and solves the puzzles received from in concurrently,
+// sending one Result per puzzle on the returned channel. The channel is closed
+// once in is closed and every puzzle has been handled, or once ctx is done.
+// Callers must either drain the channel or cancel ctx.
+func SolveBatch(ctx context.Context, in <-chan string, opts BatchOptions) <-chan Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// In order, each puzzle takes a slot until its result is sent.
+	var slots chan struct{}
+	if opts.Ordered {
+		slots = make(chan struct{}, workers)
+	}
+
+	jobs := make(chan batchJob)
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			select {
+			case <-ctx.Done():
+				return
+			case puzzle, ok := <-in:
+				if !ok {
+					return
+				}
+				if slots != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case slots <- struct{}{}:
+					}
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case jobs <- batchJob{index: index, puzzle: puzzle}:
+				}
+			}
+		}
+	}()
+
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				select {
+				case <-ctx.Done():
+					return
+				case results <- solveJob(ctx, job, opts.Solve):
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	if !opts.Ordered {
+		return results
+	}
+	return orderResults(ctx, results, slots)
+}
+
+func solveJob(ctx context.Context, job batchJob, opts SolveOptions) Result {
+	result := Result{Index: job.index, Puzzle: job.puzzle}
+
+	board, err := NewBoard(job.puzzle)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Solution, result.Stats, result.Err = SolveWithStats(ctx, board, opts)
+	return result
+}
+
+// orderResults forwards results by increasing index, holding back the ones
+// that arrive early, and frees the slot of each result sent.
+func orderResults(ctx context.Context, results <-chan Result, slots chan struct{}) <-chan Result {
+	ordered := make(chan Result)
+
+	go func() {
+		defer close(ordered)
+
+		next := 0
+		pending := make(map[int]Result)
+		for result := range results {
+			pending[result.Index] = result
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				select {
+				case <-ctx.Done():
+					return
+				case ordered <- r:
+					<-slots
+				}
+			}
+		}
+	}()
+	return ordered
+}
@@ -0,0 +1,82 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func feed(puzzles []string) <-chan string {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, puzzle := range puzzles {
+			in <- puzzle
+		}
+	}()
+	return in
+}
+
+func TestSolveBatch(t *testing.T) {
+	puzzles := append(easyProblems[:10:10], hardProblems[:10]...)
+
+	t.Run("unordered", func(t *testing.T) {
+		results := sudoku.SolveBatch(context.Background(), feed(puzzles), sudoku.BatchOptions{Workers: 4})
+
+		seen := make(map[int]struct{})
+		for result := range results {
+			assert.NoError(t, result.Err)
+			assert.Equal(t, puzzles[result.Index], result.Puzzle)
+			assert.NotContains(t, result.Solution.String(), ".")
+			assert.Positive(t, result.Stats.Nodes)
+			seen[result.Index] = struct{}{}
+		}
+		assert.Len(t, seen, len(puzzles))
+	})
+
+	t.Run("ordered", func(t *testing.T) {
+		results := sudoku.SolveBatch(context.Background(), feed(puzzles), sudoku.BatchOptions{Workers: 4, Ordered: true})
+
+		index := 0
+		for result := range results {
+			assert.Equal(t, index, result.Index)
+			assert.Equal(t, puzzles[index], result.Puzzle)
+			assert.NoError(t, result.Err)
+			index++
+		}
+		assert.Equal(t, len(puzzles), index)
+	})
+
+	t.Run("per-puzzle errors", func(t *testing.T) {
+		puzzles := []string{
+			easyProblems[0],
+			"1234567",
+			noSolutionProblem,
+			"33...............................................................................",
+		}
+		opts := sudoku.BatchOptions{Ordered: true, Solve: sudoku.SolveOptions{MaxNodes: 1}}
+
+		var results []sudoku.Result
+		for result := range sudoku.SolveBatch(context.Background(), feed(puzzles), opts) {
+			results = append(results, result)
+		}
+
+		assert.Len(t, results, 4)
+		assert.NoError(t, results[0].Err)
+		assert.ErrorIs(t, results[1].Err, sudoku.ErrInvalidBoardString)
+		assert.ErrorIs(t, results[2].Err, sudoku.ErrSearchLimit)
+		assert.ErrorIs(t, results[3].Err, sudoku.ErrDuplicateValue)
+	})
+
+	t.Run("cancelled context closes the results", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan string)
+		results := sudoku.SolveBatch(ctx, in, sudoku.BatchOptions{Workers: 2})
+
+		cancel()
+		for range results {
+		}
+	})
+}
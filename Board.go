@@ -4,6 +4,7 @@ package sudoku
 
 import (
 	"fmt"
+	"math/bits"
 	"strings"
 )
 
@@ -21,68 +22,94 @@ var (
 	ErrInvalidPosition    = fmt.Errorf("invalid position")
 	ErrInvalidValue       = fmt.Errorf("invalid value")
 	ErrDuplicateValue     = fmt.Errorf("value already exists in unit")
+	ErrGivenSquare        = fmt.Errorf("square holds a given")
 
-	allValues = "123456789"
-
-	// peers is a map where the key is a square's coordinates (row, column),
-	// and the value is a slice of coordinates of its peers.
-	peers map[[2]int][][2]int
+	// allValues is the bitmask of a square where every value is possible.
+	allValues uint16 = 1<<numDigits - 1
 )
 
-func init() {
-	peers = make(map[[2]int][][2]int)
-	for r := range numRows {
-		for c := range numColumns {
-			key := [2]int{r, c}
-			peerSet := make(map[[2]int]struct{})
-
-			// Row peers
-			for i := range numColumns {
-				peerSet[[2]int{r, i}] = struct{}{}
-			}
-
-			// Column peers
-			for i := range numRows {
-				peerSet[[2]int{i, c}] = struct{}{}
-			}
-
-			// Box peers
-			boxRowStart := (r / 3) * 3
-			boxColStart := (c / 3) * 3
-			for i := boxRowStart; i < boxRowStart+3; i++ {
-				for j := boxColStart; j < boxColStart+3; j++ {
-					peerSet[[2]int{i, j}] = struct{}{}
-				}
-			}
+// BoardError locates the problem in a board string, a value that could not be
+// set or removed, or a solution rejected by VerifySolution. It wraps the
+// sentinel error describing the problem.
+type BoardError struct {
+	Err error
+
+	// Row, Column and Value locate the rejected value.
+	Row    int
+	Column int
+	Value  int
+	// Peer is the square already holding Value, if one does, and Unit is the
+	// kind of unit it shares with the rejected square. When Peer is nil, the
+	// value was ruled out by constraint propagation instead.
+	Peer *Square
+	Unit UnitKind
+
+	// Found and Expected are the number of squares read from an invalid
+	// board string and the number required.
+	Found    int
+	Expected int
+}
 
-			delete(peerSet, key)
+func (e *BoardError) Error() string {
+	if e.Err == ErrInvalidBoardString {
+		return fmt.Sprintf("%v: found %d squares, expected %d", e.Err, e.Found, e.Expected)
+	}
 
-			peerList := make([][2]int, 0, len(peerSet))
-			for p := range peerSet {
-				peerList = append(peerList, p)
-			}
-			peers[key] = peerList
-		}
+	square := Square{Row: e.Row, Column: e.Column}
+	if e.Value == EmptySquare {
+		return fmt.Sprintf("%v: %v", e.Err, square)
 	}
+	if e.Peer != nil {
+		return fmt.Sprintf("%v: %d at %v is already in the same %v at %v", e.Err, e.Value, square, e.Unit, *e.Peer)
+	}
+	return fmt.Sprintf("%v: %d at %v", e.Err, e.Value, square)
+}
+
+func (e *BoardError) Unwrap() error {
+	return e.Err
 }
 
-type Board [numRows][numColumns]string
+// Board is a sudoku grid. Each square holds the set of its possible values as
+// a bitmask, where bit i is set if value i+1 is possible. Squares are indexed
+// from 0 to 80 in row-major order.
+type Board struct {
+	squares [numSquares]uint16
+	// givens holds the values of the board string the board was created
+	// from, or EmptySquare.
+	givens [numSquares]uint8
+	// entries holds the values set with SetValue, or EmptySquare.
+	entries [numSquares]uint8
+	// removed holds the candidates removed with RemoveCandidate.
+	removed [numSquares]uint16
+	// raw is set until constraints are propagated on a board created by
+	// NewRawBoard. Values set on a raw board are stored as they are.
+	raw bool
+
+	// history records the moves made on the board, if enabled.
+	history *history
+}
 
 func newEmptyBoard() *Board {
 	board := &Board{}
 
-	for i := range numRows {
-		for j := range numColumns {
-			board[i][j] = allValues
-		}
+	for i := range numSquares {
+		board.squares[i] = allValues
 	}
 
 	return board
 }
 
-func (b *Board) insertValues(str string) error {
+// valueBit returns the bitmask with only the given value possible.
+func valueBit(value int) uint16 {
+	return 1 << (value - 1)
+}
+
+// parseBoardString reads the values of a board string, in row-major order.
+// Empty squares hold EmptySquare. An empty string is an empty board.
+func parseBoardString(str string) ([numSquares]int, error) {
+	var values [numSquares]int
 	if str == "" {
-		return nil
+		return values, nil
 	}
 
 	// Sanitize input string. Keep relevant characters only
@@ -94,35 +121,46 @@ func (b *Board) insertValues(str string) error {
 	}
 
 	if cleanStr.Len() != numSquares {
-		return ErrInvalidBoardString
+		return values, &BoardError{Err: ErrInvalidBoardString, Found: cleanStr.Len(), Expected: numSquares}
 	}
 
 	for i, c := range cleanStr.String() {
-		if isEmptyChar(c) {
-			continue
+		if !isEmptyChar(c) {
+			values[i] = int(c - '0')
 		}
+	}
+	return values, nil
+}
 
-		value := int(c - '0')
-		row := i / numColumns
-		column := i % numColumns
+func (b *Board) insertValues(str string) error {
+	values, err := parseBoardString(str)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if value == EmptySquare {
+			continue
+		}
 
-		if err := b.assign(row, column, value); err != nil {
+		if err := b.setValue(i, value); err != nil {
 			return err
 		}
+		b.givens[i] = uint8(value)
 	}
 	return nil
 }
 
-func (b *Board) eliminate(row, column int) bool {
-	switch len(b[row][column]) {
+func (b *Board) eliminate(square int) bool {
+	switch bits.OnesCount16(b.squares[square]) {
 	case 0:
 		// Contradiction: removed last value.
 		return false
 	case 1:
 		// If a square is reduced to one value, then eliminate it from its peers.
-		value := b[row][column][0]
-		for _, p := range peers[[2]int{row, column}] {
-			if !b.eliminateSquare(p[0], p[1], value) {
+		value := b.squares[square]
+		for _, p := range peerSquares[square] {
+			if !b.eliminateSquare(p, value) {
 				return false
 			}
 		}
@@ -141,28 +179,99 @@ func NewBoard(str string) (*Board, error) {
 	return board, nil
 }
 
-func (b *Board) Duplicate() *Board {
-	newBoard := &Board{}
-	for i := range numRows {
-		for j := range numColumns {
-			newBoard[i][j] = b[i][j]
+// NewRawBoard creates a sudoku grid from a string without propagating
+// constraints: empty squares keep every value possible, and values breaking
+// the rules are accepted. Call Propagate to apply the constraints.
+func NewRawBoard(str string) (*Board, error) {
+	board := newEmptyBoard()
+	board.raw = true
+
+	if err := board.insertValues(str); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// Propagate removes the value of each square from the possible values of its
+// peers, filling the squares left with a single possible value, as NewBoard
+// does. It returns ErrContradiction if this leaves a square without possible
+// values, in which case the board is left partially propagated.
+func (b *Board) Propagate() error {
+	b.raw = false
+	if !b.propagate() {
+		return ErrContradiction
+	}
+	return nil
+}
+
+func (b *Board) propagate() bool {
+	for i := range numSquares {
+		if !b.eliminate(i) {
+			return false
 		}
 	}
-	return newBoard
+	return true
 }
 
-func (b *Board) assign(row, column, value int) error {
-	if !b.valuePossible(row, column, value) {
-		return ErrDuplicateValue
+// Duplicate returns a copy of the board, without its move history.
+func (b *Board) Duplicate() *Board {
+	newBoard := *b
+	newBoard.history = nil
+	return &newBoard
+}
+
+// assign sets the value of a square and propagates it to the peers. It returns
+// false if the value is not possible or if propagation hit a contradiction.
+func (b *Board) assign(square, value int) bool {
+	if !b.valuePossible(square, value) {
+		return false
 	}
 
-	b[row][column] = string(byte(value + '0'))
-	if !b.eliminate(row, column) {
-		return ErrDuplicateValue
+	b.squares[square] = valueBit(value)
+	return b.eliminate(square)
+}
+
+// setValue is like assign, but describes why the value was rejected. On a raw
+// board, the value is stored without any check.
+func (b *Board) setValue(square, value int) error {
+	if b.raw {
+		b.squares[square] = valueBit(value)
+		return nil
+	}
+	if !b.valuePossible(square, value) {
+		return b.duplicateError(square, value)
+	}
+	if !b.assign(square, value) {
+		return valueError(ErrDuplicateValue, square, value)
 	}
 	return nil
 }
 
+func valueError(err error, square, value int) *BoardError {
+	s := squareAt(square)
+	return &BoardError{Err: err, Row: s.Row, Column: s.Column, Value: value}
+}
+
+// duplicateError describes why a value is not possible in a square, pointing
+// at the peer holding it if there is one.
+func (b *Board) duplicateError(square, value int) *BoardError {
+	err := valueError(ErrDuplicateValue, square, value)
+	for _, u := range squareUnits[square] {
+		for _, p := range unitSquares[u] {
+			if p != square && b.value(p) == value {
+				peer := squareAt(p)
+				err.Peer, err.Unit = &peer, UnitKind(u/numDigits)
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SetValue sets the value of a square and propagates it to its peers, unless
+// the board is raw. A value set before in the square is replaced, but givens
+// cannot be changed. If the value is rejected, the board is left unchanged.
 func (b *Board) SetValue(row, column, value int) error {
 	if !isValidPosition(row, column) {
 		return ErrInvalidPosition
@@ -170,7 +279,75 @@ func (b *Board) SetValue(row, column, value int) error {
 	if !isValidValue(value) {
 		return ErrInvalidValue
 	}
-	return b.assign(row, column, value)
+
+	square := squareIndex(row, column)
+	switch given := int(b.givens[square]); given {
+	case EmptySquare:
+	case value:
+		return nil
+	default:
+		return valueError(ErrGivenSquare, square, value)
+	}
+
+	defer b.record()()
+	before := b.state()
+	if entry := int(b.entries[square]); entry != EmptySquare && entry != value {
+		b.entries[square] = EmptySquare
+		b.rebuild()
+	}
+	if err := b.setValue(square, value); err != nil {
+		b.restore(before)
+		return err
+	}
+	b.entries[square] = uint8(value)
+	return nil
+}
+
+// ClearValue removes a value set with SetValue, recomputing the candidates of
+// every square from the givens, the other values set and the candidates
+// removed. Squares without a value set are left unchanged, and givens cannot
+// be cleared.
+func (b *Board) ClearValue(row, column int) error {
+	if !isValidPosition(row, column) {
+		return ErrInvalidPosition
+	}
+
+	square := squareIndex(row, column)
+	if given := int(b.givens[square]); given != EmptySquare {
+		return valueError(ErrGivenSquare, square, given)
+	}
+	if b.entries[square] == EmptySquare {
+		return nil
+	}
+
+	defer b.record()()
+	b.entries[square] = EmptySquare
+	b.rebuild()
+	return nil
+}
+
+// rebuild recomputes the candidates of every square from the givens, the
+// values set and the candidates removed.
+func (b *Board) rebuild() {
+	for i := range numSquares {
+		b.squares[i] = allValues
+	}
+
+	// Every value and removal was accepted before, and constraint
+	// propagation reaches the same result in any order, so none of them can
+	// be rejected now.
+	for _, values := range [][numSquares]uint8{b.givens, b.entries} {
+		for i, value := range values {
+			if value != EmptySquare {
+				_ = b.setValue(i, int(value))
+			}
+		}
+	}
+	for i, mask := range b.removed {
+		for ; mask != 0; mask &= mask - 1 {
+			_ = b.removeCandidate(i, bits.TrailingZeros16(mask)+1)
+		}
+	}
 }
 
 func (b *Board) CountPossible(row, column int) (int, error) {
@@ -178,22 +355,37 @@ func (b *Board) CountPossible(row, column int) (int, error) {
 		return 0, ErrInvalidPosition
 	}
 
-	return len(b[row][column]), nil
+	return b.countPossible(squareIndex(row, column)), nil
+}
+
+func (b *Board) countPossible(square int) int {
+	return bits.OnesCount16(b.squares[square])
+}
+
+// candidateCount returns the total number of possible values on the board.
+func (b *Board) candidateCount() int {
+	count := 0
+	for _, mask := range b.squares {
+		count += bits.OnesCount16(mask)
+	}
+	return count
 }
 
-func (b *Board) eliminateSquare(row, column int, value byte) bool {
-	if strings.ContainsRune(b[row][column], rune(value)) {
-		b[row][column] = strings.ReplaceAll(b[row][column], string(value), "")
+// eliminateSquare removes the values of the mask from the square's possible
+// values, propagating the change to its peers.
+func (b *Board) eliminateSquare(square int, mask uint16) bool {
+	if b.squares[square]&mask != 0 {
+		b.squares[square] &^= mask
 
-		if !b.eliminate(row, column) {
+		if !b.eliminate(square) {
 			return false
 		}
 	}
 	return true
 }
 
-func (b *Board) valuePossible(row, column int, value int) bool {
-	return strings.ContainsRune(b[row][column], rune(value+'0'))
+func (b *Board) valuePossible(square int, value int) bool {
+	return b.squares[square]&valueBit(value) != 0
 }
 
 func (b *Board) GetValue(row, column int) (int, error) {
@@ -201,22 +393,65 @@ func (b *Board) GetValue(row, column int) (int, error) {
 		return -1, ErrInvalidPosition
 	}
 
-	if len(b[row][column]) > 1 {
-		return EmptySquare, nil
+	return b.value(squareIndex(row, column)), nil
+}
+
+// value returns the value of a square, or EmptySquare if it has several or no
+// possible values.
+func (b *Board) value(square int) int {
+	mask := b.squares[square]
+	if bits.OnesCount16(mask) != 1 {
+		return EmptySquare
 	}
-	return int(b[row][column][0] - '0'), nil
+	return bits.TrailingZeros16(mask) + 1
 }
 
+// IsGiven reports whether the square's value was part of the board string the
+// board was created from, rather than deduced or set afterwards.
+func (b *Board) IsGiven(row, column int) (bool, error) {
+	if !isValidPosition(row, column) {
+		return false, ErrInvalidPosition
+	}
+
+	return b.givens[squareIndex(row, column)] != EmptySquare, nil
+}
+
+// Givens returns the squares whose values were given, in row-major order.
+func (b *Board) Givens() []Square {
+	var givens []Square
+	for i, value := range b.givens {
+		if value != EmptySquare {
+			givens = append(givens, squareAt(i))
+		}
+	}
+	return givens
+}
+
+// PuzzleString returns the board string of the givens only, in the same
+// format as String.
+func (b *Board) PuzzleString() string {
+	var str strings.Builder
+
+	for _, value := range b.givens {
+		if value == EmptySquare {
+			str.WriteByte('.')
+		} else {
+			str.WriteByte(value + '0')
+		}
+	}
+	return str.String()
+}
+
+// String returns the values of the board in row-major order, with '.' for
+// empty squares. Values deduced by constraint propagation are included.
 func (b *Board) String() string {
 	var str strings.Builder
 
-	for i := range numRows {
-		for j := range numColumns {
-			if len(b[i][j]) > 1 {
-				str.WriteByte('.')
-			} else {
-				str.WriteString(b[i][j])
-			}
+	for i := range numSquares {
+		if value := b.value(i); value == EmptySquare {
+			str.WriteByte('.')
+		} else {
+			str.WriteByte(byte(value + '0'))
 		}
 	}
 	return str.String()
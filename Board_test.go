@@ -1,6 +1,8 @@
 package sudoku_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/kroosec/sudoku-go"
@@ -174,3 +176,222 @@ func TestBoard(t *testing.T) {
 		}
 	})
 }
+
+func TestRawBoard(t *testing.T) {
+	t.Run("values are stored without propagation", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard(easyProblems[0])
+		assert.NoError(t, err)
+		assert.Equal(t, board.PuzzleString(), board.String())
+
+		possible, err := board.CountPossible(0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 9, possible)
+
+		possible, err = board.CountPossible(0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, possible)
+	})
+
+	t.Run("propagate on demand", func(t *testing.T) {
+		for _, boardString := range append(easyProblems[:10:10], hardProblems[:10]...) {
+			want, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			board, err := sudoku.NewRawBoard(boardString)
+			assert.NoError(t, err)
+			assert.NoError(t, board.Propagate())
+			assert.Equal(t, want.String(), board.String())
+		}
+	})
+
+	t.Run("rule breaking values are accepted", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard("33...............................................................................")
+		assert.NoError(t, err)
+		assert.Equal(t, "33", board.String()[:2])
+
+		assert.NoError(t, board.SetValue(1, 0, 3))
+		assert.Equal(t, "3", board.String()[9:10])
+
+		assert.ErrorIs(t, board.Propagate(), sudoku.ErrContradiction)
+	})
+
+	t.Run("set values after propagation", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard(hardProblems[0])
+		assert.NoError(t, err)
+		assert.NoError(t, board.Propagate())
+
+		assert.ErrorIs(t, board.SetValue(0, 1, 4), sudoku.ErrDuplicateValue)
+	})
+
+	t.Run("invalid board string", func(t *testing.T) {
+		_, err := sudoku.NewRawBoard("1234567")
+		assert.ErrorIs(t, err, sudoku.ErrInvalidBoardString)
+	})
+
+	t.Run("solve raw boards", func(t *testing.T) {
+		for _, boardString := range hardProblems[:5] {
+			want, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			board, err := sudoku.NewRawBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, err := sudoku.Solve(board)
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(want).String(), solved.String())
+			assert.Equal(t, boardString, board.String())
+		}
+	})
+}
+
+func TestGivens(t *testing.T) {
+	puzzle := "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+
+	board, err := sudoku.NewBoard(easyProblems[0])
+	assert.NoError(t, err)
+
+	t.Run("puzzle string keeps only the givens", func(t *testing.T) {
+		assert.Equal(t, strings.ReplaceAll(puzzle, "0", "."), board.PuzzleString())
+		assert.NotContains(t, board.String(), ".")
+	})
+
+	t.Run("given and deduced squares", func(t *testing.T) {
+		given, err := board.IsGiven(0, 2)
+		assert.NoError(t, err)
+		assert.True(t, given)
+
+		given, err = board.IsGiven(0, 0)
+		assert.NoError(t, err)
+		assert.False(t, given)
+
+		value, err := board.GetValue(0, 0)
+		assert.NoError(t, err)
+		assert.NotEqual(t, sudoku.EmptySquare, value)
+
+		_, err = board.IsGiven(-1, 0)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidPosition)
+	})
+
+	t.Run("list the givens", func(t *testing.T) {
+		givens := board.Givens()
+		assert.Len(t, givens, 81-strings.Count(puzzle, "0"))
+		assert.Equal(t, sudoku.Square{Row: 0, Column: 2}, givens[0])
+		assert.Equal(t, sudoku.Square{Row: 8, Column: 6}, givens[len(givens)-1])
+	})
+
+	t.Run("set values are not givens", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		assert.NoError(t, board.SetValue(0, 1, 1))
+
+		given, err := board.IsGiven(0, 1)
+		assert.NoError(t, err)
+		assert.False(t, given)
+		assert.Equal(t, hardProblems[0], board.PuzzleString())
+	})
+
+	t.Run("solutions keep the givens", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.Equal(t, hardProblems[0], sudoku.Solver(board).PuzzleString())
+		assert.Equal(t, hardProblems[0], board.Duplicate().PuzzleString())
+	})
+}
+
+func TestBoardError(t *testing.T) {
+	t.Run("wrong number of squares", func(t *testing.T) {
+		_, err := sudoku.NewBoard("1234567")
+
+		var boardErr *sudoku.BoardError
+		assert.True(t, errors.As(err, &boardErr))
+		assert.ErrorIs(t, err, sudoku.ErrInvalidBoardString)
+		assert.Equal(t, 7, boardErr.Found)
+		assert.Equal(t, 81, boardErr.Expected)
+		assert.EqualError(t, err, "invalid board string: found 7 squares, expected 81")
+	})
+
+	t.Run("duplicate values", func(t *testing.T) {
+		type testCase struct {
+			name        string
+			boardString string
+			row         int
+			column      int
+			value       int
+			peer        sudoku.Square
+			unit        sudoku.UnitKind
+			message     string
+		}
+
+		cases := []testCase{
+			{"Same values in a column", "3........3.......................................................................",
+				1, 0, 3, sudoku.Square{Row: 0, Column: 0}, sudoku.ColumnUnit,
+				"value already exists in unit: 3 at r2c1 is already in the same column at r1c1"},
+			{"Same values in a row", "33...............................................................................",
+				0, 1, 3, sudoku.Square{Row: 0, Column: 0}, sudoku.RowUnit,
+				"value already exists in unit: 3 at r1c2 is already in the same row at r1c1"},
+			{"Same values in a box", ".3.........3.....................................................................",
+				1, 2, 3, sudoku.Square{Row: 0, Column: 1}, sudoku.BoxUnit,
+				"value already exists in unit: 3 at r2c3 is already in the same box at r1c2"},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				_, err := sudoku.NewBoard(c.boardString)
+
+				var boardErr *sudoku.BoardError
+				assert.True(t, errors.As(err, &boardErr))
+				assert.ErrorIs(t, err, sudoku.ErrDuplicateValue)
+				assert.Equal(t, c.row, boardErr.Row)
+				assert.Equal(t, c.column, boardErr.Column)
+				assert.Equal(t, c.value, boardErr.Value)
+				assert.Equal(t, &c.peer, boardErr.Peer)
+				assert.Equal(t, c.unit, boardErr.Unit)
+				assert.EqualError(t, err, c.message)
+			})
+		}
+	})
+
+	t.Run("set value", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		err = board.SetValue(8, 8, 5)
+		var boardErr *sudoku.BoardError
+		assert.True(t, errors.As(err, &boardErr))
+		assert.Equal(t, sudoku.Square{Row: 0, Column: 8}, *boardErr.Peer)
+		assert.Equal(t, sudoku.ColumnUnit, boardErr.Unit)
+	})
+
+	t.Run("value ruled out by propagation", func(t *testing.T) {
+		board, err := sudoku.NewBoard(easyProblems[1])
+		assert.NoError(t, err)
+
+		err = board.SetValue(0, 1, 1)
+		var boardErr *sudoku.BoardError
+		assert.True(t, errors.As(err, &boardErr))
+		assert.Nil(t, boardErr.Peer)
+		assert.EqualError(t, err, "value already exists in unit: 1 at r1c2")
+	})
+}
+
+func BenchmarkNewBoard(b *testing.B) {
+	for b.Loop() {
+		for _, boardString := range easyProblems {
+			if _, err := sudoku.NewBoard(boardString); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDuplicate(b *testing.B) {
+	board, err := sudoku.NewBoard(hardProblems[0])
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		board.Duplicate()
+	}
+}
@@ -0,0 +1,82 @@
+package sudoku
+
+import "math/bits"
+
+// Candidates returns the values still possible in a square, in increasing
+// order. A square holding a value has that value as its only candidate.
+func (b *Board) Candidates(row, column int) ([]int, error) {
+	if !isValidPosition(row, column) {
+		return nil, ErrInvalidPosition
+	}
+
+	mask := b.squares[squareIndex(row, column)]
+	candidates := make([]int, 0, bits.OnesCount16(mask))
+	for ; mask != 0; mask &= mask - 1 {
+		candidates = append(candidates, bits.TrailingZeros16(mask)+1)
+	}
+	return candidates, nil
+}
+
+// HasCandidate reports whether a value is still possible in a square.
+func (b *Board) HasCandidate(row, column, value int) (bool, error) {
+	if !isValidPosition(row, column) {
+		return false, ErrInvalidPosition
+	}
+	if !isValidValue(value) {
+		return false, ErrInvalidValue
+	}
+
+	return b.valuePossible(squareIndex(row, column), value), nil
+}
+
+// RemoveCandidate rules out a value in a square. If this leaves a single
+// candidate, the square takes that value and constraints are propagated to
+// its peers, unless the board is raw. Removing the last candidate of a square
+// is rejected with ErrContradiction, and so is a removal whose propagation
+// hits a contradiction. A rejected removal leaves the board unchanged.
+func (b *Board) RemoveCandidate(row, column, value int) error {
+	if !isValidPosition(row, column) {
+		return ErrInvalidPosition
+	}
+	if !isValidValue(value) {
+		return ErrInvalidValue
+	}
+
+	square := squareIndex(row, column)
+	defer b.record()()
+	before := b.state()
+	if err := b.removeCandidate(square, value); err != nil {
+		b.restore(before)
+		return err
+	}
+	b.removed[square] |= valueBit(value)
+	return nil
+}
+
+func (b *Board) removeCandidate(square, value int) error {
+	mask := valueBit(value)
+	switch {
+	case b.squares[square]&mask == 0:
+		return nil
+	case b.squares[square] == mask:
+		return valueError(ErrContradiction, square, value)
+	case b.raw:
+		b.squares[square] &^= mask
+		return nil
+	}
+
+	if !b.eliminateSquare(square, mask) {
+		return valueError(ErrContradiction, square, value)
+	}
+	return nil
+}
+
+// ResetCandidates recomputes the candidates of every square from the givens,
+// discarding the values set and the candidates removed since the board was
+// created.
+func (b *Board) ResetCandidates() {
+	defer b.record()()
+	b.entries = [numSquares]uint8{}
+	b.removed = [numSquares]uint16{}
+	b.rebuild()
+}
@@ -0,0 +1,128 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidates(t *testing.T) {
+	t.Run("read candidates", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		candidates, err := board.Candidates(0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{4}, candidates)
+
+		// Row 1 holds 4, 8 and 5, column 2 holds 3 and 2, box 1 holds 4 and 3.
+		candidates, err = board.Candidates(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 6, 7, 9}, candidates)
+
+		has, err := board.HasCandidate(0, 1, 6)
+		assert.NoError(t, err)
+		assert.True(t, has)
+
+		has, err = board.HasCandidate(0, 1, 2)
+		assert.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		board, err := sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		_, err = board.Candidates(9, 0)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidPosition)
+
+		_, err = board.HasCandidate(0, 9, 1)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidPosition)
+
+		_, err = board.HasCandidate(0, 0, 10)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidValue)
+
+		assert.ErrorIs(t, board.RemoveCandidate(-1, 0, 1), sudoku.ErrInvalidPosition)
+		assert.ErrorIs(t, board.RemoveCandidate(0, 0, 0), sudoku.ErrInvalidValue)
+	})
+
+	t.Run("remove candidates and propagate", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		for _, value := range []int{1, 6, 7} {
+			assert.NoError(t, board.RemoveCandidate(0, 1, value))
+		}
+		// Removing a candidate twice has no effect.
+		assert.NoError(t, board.RemoveCandidate(0, 1, 7))
+
+		value, err := board.GetValue(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 9, value)
+
+		has, err := board.HasCandidate(0, 2, 9)
+		assert.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("removing the last candidate is rejected", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, board.RemoveCandidate(0, 0, 4), sudoku.ErrContradiction)
+
+		value, err := board.GetValue(0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, value)
+	})
+
+	t.Run("rejected removals leave the board unchanged", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.NoError(t, board.RemoveCandidate(6, 4, 4))
+		want := board.String()
+		candidates, err := board.Candidates(6, 4)
+		assert.NoError(t, err)
+
+		// Propagating the candidate left in r7c5 hits a contradiction.
+		assert.ErrorIs(t, board.RemoveCandidate(6, 4, 5), sudoku.ErrContradiction)
+		assert.Equal(t, want, board.String())
+		assert.True(t, board.IsValid())
+
+		after, err := board.Candidates(6, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, candidates, after)
+	})
+
+	t.Run("raw boards do not propagate", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		for value := 2; value <= 9; value++ {
+			assert.NoError(t, board.RemoveCandidate(0, 1, value))
+		}
+
+		has, err := board.HasCandidate(0, 2, 1)
+		assert.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("reset candidates from the givens", func(t *testing.T) {
+		want, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		board := want.Duplicate()
+		assert.NoError(t, board.RemoveCandidate(0, 1, 1))
+		assert.NoError(t, board.SetValue(0, 2, 9))
+		assert.NotEqual(t, want.String(), board.String())
+
+		board.ResetCandidates()
+		assert.Equal(t, want.String(), board.String())
+
+		candidates, err := board.Candidates(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 6, 7, 9}, candidates)
+	})
+}
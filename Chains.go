@@ -0,0 +1,412 @@
+package sudoku
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// xyWing rules out z from the squares seeing two pincers xz and yz, when both
+// see a pivot xy: whichever value the pivot holds, one pincer holds z.
+var xyWing = technique{name: "XY-Wing", find: func(g *grid) *Step {
+	for pivot, mask := range g.candidates {
+		if bits.OnesCount16(mask) != 2 {
+			continue
+		}
+
+		pincers := g.bivaluePeers(pivot, func(m uint16) bool {
+			return bits.OnesCount16(m&mask) == 1
+		})
+		for i, a := range pincers {
+			for _, b := range pincers[i+1:] {
+				z := g.candidates[a] & g.candidates[b]
+				if bits.OnesCount16(z) != 1 || z&mask != 0 || g.candidates[a]|g.candidates[b] != mask|z {
+					continue
+				}
+
+				step := &Step{}
+				g.eliminate(step, seenByAll(a, b), z)
+				if step := newStep(step, []int{pivot, a, b}, mask|z); step != nil {
+					return step.because("Whichever of %v the pivot %v holds, %v or %v holds %v",
+						valueMask(mask), squareAt(pivot), squareAt(a), squareAt(b), valueMask(z))
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// xyzWing is like xyWing, except that the pivot has z as candidate too, so z is
+// only ruled out from the squares seeing the pivot as well.
+var xyzWing = technique{name: "XYZ-Wing", find: func(g *grid) *Step {
+	for pivot, mask := range g.candidates {
+		if bits.OnesCount16(mask) != 3 {
+			continue
+		}
+
+		pincers := g.bivaluePeers(pivot, func(m uint16) bool {
+			return m&^mask == 0
+		})
+		for i, a := range pincers {
+			for _, b := range pincers[i+1:] {
+				z := g.candidates[a] & g.candidates[b]
+				if bits.OnesCount16(z) != 1 {
+					continue
+				}
+
+				step := &Step{}
+				g.eliminate(step, seenByAll(pivot, a, b), z)
+				if step := newStep(step, []int{pivot, a, b}, mask); step != nil {
+					return step.because("Whichever of %v the pivot %v holds, it, %v or %v holds %v",
+						valueMask(mask), squareAt(pivot), squareAt(a), squareAt(b), valueMask(z))
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// wWing rules out y from the squares seeing two squares xy, when the only two
+// candidates x of a unit see one square each: one of the squares holds y.
+var wWing = technique{name: "W-Wing", find: func(g *grid) *Step {
+	for a, mask := range g.candidates {
+		if bits.OnesCount16(mask) != 2 {
+			continue
+		}
+
+		for b := a + 1; b < numSquares; b++ {
+			if g.candidates[b] != mask || sees(a, b) {
+				continue
+			}
+
+			for _, x := range maskValues(mask) {
+				for u := range numUnits {
+					positions := g.positions(u, x)
+					if bits.OnesCount16(positions) != 2 {
+						continue
+					}
+
+					p := unitSquares[u][bits.TrailingZeros16(positions)]
+					q := unitSquares[u][bits.Len16(positions)-1]
+					if !(sees(a, p) && sees(b, q)) && !(sees(a, q) && sees(b, p)) {
+						continue
+					}
+
+					y := mask &^ valueBit(x)
+					step := &Step{Units: unitsOf(u)}
+					g.eliminate(step, seenByAll(a, b), y)
+					if step := newStep(step, []int{a, p, q, b}, mask); step != nil {
+						return step.because("%v and %v both hold %d or %v, and as %d can only go in %v or %v in %v, each seeing one of them, they cannot both hold %d: one of them holds %v",
+							squareAt(a), squareAt(b), x, valueMask(y), x, squareAt(p), squareAt(q), unitAt(u), x, valueMask(y))
+					}
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// simpleColoring colors the candidates of a value linked by conjugate pairs,
+// the only two candidates of a unit, with alternating colors: one color holds
+// the value. A color with two squares seeing each other is ruled out, and so
+// are the candidates seeing both colors.
+var simpleColoring = technique{name: "Simple Coloring", find: func(g *grid) *Step {
+	for value := 1; value <= numDigits; value++ {
+		for _, cluster := range g.clusters(value) {
+			for color := range 2 {
+				for i, a := range cluster[color] {
+					for _, b := range cluster[color][i+1:] {
+						if !sees(a, b) {
+							continue
+						}
+
+						step := &Step{}
+						g.eliminate(step, cluster[color], valueBit(value))
+						if step := newStep(step, cluster.squares(), valueBit(value)); step != nil {
+							return step.because("Coloring the conjugate pairs of %d alternately, %v and %v have the same color but see each other: that color cannot hold %d",
+								value, squareAt(a), squareAt(b), value)
+						}
+					}
+				}
+			}
+
+			step := &Step{}
+			g.eliminate(step, g.seeingBoth(cluster[0], cluster[1], cluster.squares()), valueBit(value))
+			if step := newStep(step, cluster.squares(), valueBit(value)); step != nil {
+				return step.because("Coloring the conjugate pairs of %d alternately, either the squares %v or the squares %v hold %d",
+					value, squareList(cluster[0]), squareList(cluster[1]), value)
+			}
+		}
+	}
+	return nil
+}}
+
+// multiColoring works on two clusters of simpleColoring. When a color of each
+// see each other, one of the other two colors holds the value, so the
+// candidates seeing both are ruled out.
+var multiColoring = technique{name: "Multi-Coloring", find: func(g *grid) *Step {
+	for value := 1; value <= numDigits; value++ {
+		clusters := g.clusters(value)
+		for i, a := range clusters {
+			for _, b := range clusters[i+1:] {
+				for ca := range 2 {
+					for cb := range 2 {
+						if !anySees(a[ca], b[cb]) {
+							continue
+						}
+
+						pattern := append(a.squares(), b.squares()...)
+						step := &Step{}
+						g.eliminate(step, g.seeingBoth(a[1-ca], b[1-cb], pattern), valueBit(value))
+						if step := newStep(step, pattern, valueBit(value)); step != nil {
+							return step.because("Coloring the conjugate pairs of %d alternately, the squares %v of a cluster see the squares %v of another and as they cannot both hold %d, either the squares %v or the squares %v do",
+								value, squareList(a[ca]), squareList(b[cb]), value, squareList(a[1-ca]), squareList(b[1-cb]))
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// xChain rules out a value from the squares seeing both ends of an alternating
+// chain of strong links, conjugate pairs of the value, and weak links, squares
+// seeing each other: one of the ends holds the value.
+var xChain = technique{name: "X-Chain", find: func(g *grid) *Step {
+	return g.findChain(2, func(node int) []int {
+		square, value := node/numDigits, node%numDigits+1
+		var links []int
+		for _, u := range squareUnits[square] {
+			positions := g.positions(u, value)
+			if bits.OnesCount16(positions) != 2 {
+				continue
+			}
+			for ; positions != 0; positions &= positions - 1 {
+				if s := unitSquares[u][bits.TrailingZeros16(positions)]; s != square {
+					links = append(links, candidateNode(s, value))
+				}
+			}
+		}
+		return links
+	}, func(node int) []int {
+		square, value := node/numDigits, node%numDigits+1
+		var links []int
+		for _, p := range peerSquares[square] {
+			if g.candidates[p]&valueBit(value) != 0 {
+				links = append(links, candidateNode(p, value))
+			}
+		}
+		return links
+	})
+}}
+
+// xyChain rules out a value from the squares seeing both ends of a chain of
+// squares with two candidates, each sharing a value with the next one, which
+// starts and ends with the value: one of the ends holds it.
+var xyChain = technique{name: "XY-Chain", find: func(g *grid) *Step {
+	return g.findChain(1, func(node int) []int {
+		square := node / numDigits
+		if bits.OnesCount16(g.candidates[square]) != 2 {
+			return nil
+		}
+		other := g.candidates[square] &^ valueBit(node%numDigits+1)
+		return []int{candidateNode(square, bits.TrailingZeros16(other)+1)}
+	}, func(node int) []int {
+		square, value := node/numDigits, node%numDigits+1
+		var links []int
+		for _, p := range peerSquares[square] {
+			if bits.OnesCount16(g.candidates[p]) == 2 && g.candidates[p]&valueBit(value) != 0 {
+				links = append(links, candidateNode(p, value))
+			}
+		}
+		return links
+	})
+}}
+
+// bivaluePeers returns the peers of a square with two candidates matching a
+// predicate.
+func (g *grid) bivaluePeers(square int, match func(uint16) bool) []int {
+	var peers []int
+	for _, p := range peerSquares[square] {
+		if m := g.candidates[p]; bits.OnesCount16(m) == 2 && match(m) {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// seenByAll returns the squares seeing every given square.
+func seenByAll(squares ...int) []int {
+	var result []int
+	for _, p := range peerSquares[squares[0]] {
+		seen := true
+		for _, s := range squares[1:] {
+			seen = seen && sees(p, s)
+		}
+		if seen {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// anySees reports whether a square of a sees a square of b.
+func anySees(a, b []int) bool {
+	for _, s := range a {
+		for _, t := range b {
+			if sees(s, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// seeingBoth returns the squares outside a pattern seeing a square of a and a
+// square of b.
+func (g *grid) seeingBoth(a, b, pattern []int) []int {
+	var result []int
+	for s := range numSquares {
+		if g.candidates[s] != 0 && !slices.Contains(pattern, s) && anySees([]int{s}, a) && anySees([]int{s}, b) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// cluster holds the squares of each color of a group of candidates of a value
+// linked by conjugate pairs.
+type cluster [2][]int
+
+func (c cluster) squares() []int {
+	squares := append(slices.Clone(c[0]), c[1]...)
+	slices.Sort(squares)
+	return squares
+}
+
+// clusters returns the clusters of a value, in the order of their first
+// square.
+func (g *grid) clusters(value int) []cluster {
+	var clusters []cluster
+	colored := map[int]bool{}
+	for start := range numSquares {
+		if colored[start] || g.candidates[start]&valueBit(value) == 0 {
+			continue
+		}
+
+		var c cluster
+		colors := map[int]int{start: 0}
+		queue := []int{start}
+		for len(queue) > 0 {
+			s := queue[0]
+			queue = queue[1:]
+			colored[s] = true
+			c[colors[s]] = append(c[colors[s]], s)
+
+			for _, u := range squareUnits[s] {
+				positions := g.positions(u, value)
+				if bits.OnesCount16(positions) != 2 {
+					continue
+				}
+				for ; positions != 0; positions &= positions - 1 {
+					p := unitSquares[u][bits.TrailingZeros16(positions)]
+					if _, ok := colors[p]; !ok {
+						colors[p] = 1 - colors[s]
+						queue = append(queue, p)
+					}
+				}
+			}
+		}
+		if len(c[1]) > 0 {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters
+}
+
+// candidateNode returns the node of a candidate in chains.
+func candidateNode(square, value int) int {
+	return square*numDigits + value - 1
+}
+
+// chainState is a candidate reached by a chain. on is set if the candidate
+// is the value when the chain's start is not.
+type chainState struct {
+	node int
+	on   bool
+}
+
+// findChain returns the step of the shortest alternating chain found from
+// each candidate in turn, starting and ending with a strong link, having at
+// least minStrong strong links and whose ends are the same value in different
+// squares. If a candidate of a strong link is not the value, the other is,
+// and if a candidate of a weak link is the value, the other is not.
+func (g *grid) findChain(minStrong int, strong, weak func(node int) []int) *Step {
+	for s := range numSquares {
+		for mask := g.candidates[s]; mask != 0; mask &= mask - 1 {
+			start := chainState{node: candidateNode(s, bits.TrailingZeros16(mask)+1)}
+			parents := map[chainState]chainState{start: start}
+			queue := []chainState{start}
+			for len(queue) > 0 {
+				current := queue[0]
+				queue = queue[1:]
+
+				if current.on {
+					if step := g.chainStep(chainPath(parents, current), minStrong); step != nil {
+						return step
+					}
+				}
+
+				links := weak
+				if !current.on {
+					links = strong
+				}
+				for _, node := range links(current.node) {
+					next := chainState{node: node, on: !current.on}
+					if _, ok := parents[next]; !ok {
+						parents[next] = current
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// chainPath returns the candidates of the chain ending at a state, from its
+// start.
+func chainPath(parents map[chainState]chainState, end chainState) []int {
+	path := []int{end.node}
+	for current := end; parents[current] != current; {
+		current = parents[current]
+		path = append(path, current.node)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// chainStep returns the step of a chain, if its ends are the same value in
+// different squares and it rules out candidates.
+func (g *grid) chainStep(path []int, minStrong int) *Step {
+	first, last := path[0], path[len(path)-1]
+	if len(path)/2 < minStrong || first%numDigits != last%numDigits || first == last {
+		return nil
+	}
+
+	step := &Step{}
+	var squares []int
+	var values uint16
+	for _, node := range path {
+		square, value := node/numDigits, node%numDigits+1
+		step.Chain = append(step.Chain, Candidate{Square: squareAt(square), Value: value})
+		squares = append(squares, square)
+		values |= valueBit(value)
+	}
+	g.eliminate(step, seenByAll(first/numDigits, last/numDigits), valueBit(first%numDigits+1))
+	if step = newStep(step, squares, values); step == nil {
+		return nil
+	}
+	return step.because("In the chain %s, where one end of each = link is true and one end of each - link is false, %v or %v is %d",
+		joinChain(step.Chain), squareAt(first/numDigits), squareAt(last/numDigits), first%numDigits+1)
+}
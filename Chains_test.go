@@ -0,0 +1,53 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChains(t *testing.T) {
+	t.Run("xy-wing", func(t *testing.T) {
+		pivot, a, b := sudoku.Square{Row: 0, Column: 0}, sudoku.Square{Row: 0, Column: 4}, sudoku.Square{Row: 4, Column: 0}
+		board := candidateBoard(t, map[sudoku.Square][]int{pivot: {1, 2}, a: {1, 3}, b: {2, 3}})
+
+		step := findStep(t, "XY-Wing", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, []sudoku.Square{pivot, a, b}, step.Squares)
+		assert.Equal(t, []int{1, 2, 3}, step.Values)
+		assert.Equal(t, []sudoku.Candidate{{Square: sudoku.Square{Row: 4, Column: 4}, Value: 3}}, step.Eliminations)
+		assert.Empty(t, step.Chain)
+	})
+
+	t.Run("chains rule out candidates seeing both ends", func(t *testing.T) {
+		var found int
+		for _, boardString := range hardProblems {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			_, steps, _ := sudoku.NewLogicalSolver().Solve(board)
+			for _, step := range steps {
+				if step.Technique != "X-Chain" && step.Technique != "XY-Chain" {
+					continue
+				}
+				found++
+
+				// A chain starts and ends with a strong link.
+				assert.Equal(t, 0, len(step.Chain)%2)
+				first, last := step.Chain[0], step.Chain[len(step.Chain)-1]
+				assert.Equal(t, first.Value, last.Value)
+				for _, c := range step.Eliminations {
+					assert.Equal(t, first.Value, c.Value)
+					assert.True(t, seesSquare(c.Square, first.Square) && seesSquare(c.Square, last.Square), "%v", c)
+				}
+			}
+		}
+		assert.NotZero(t, found)
+	})
+}
+
+// seesSquare reports whether two different squares share a unit.
+func seesSquare(a, b sudoku.Square) bool {
+	return a != b && (a.Row == b.Row || a.Column == b.Column || (a.Row/3 == b.Row/3 && a.Column/3 == b.Column/3))
+}
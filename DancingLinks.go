@@ -0,0 +1,212 @@
+package sudoku
+
+import (
+	"context"
+	"math/bits"
+)
+
+const (
+	// Each square, each row/value, each column/value and each box/value pair
+	// is a constraint that must be satisfied exactly once.
+	numConstraints = 4 * numSquares
+	// Each square/value pair is an option covering four constraints.
+	numOptions = numSquares * numDigits
+)
+
+// DancingLinks solves boards with Knuth's Algorithm X, using dancing links to
+// search for an exact cover of the sudoku constraints. It is faster than
+// Backtracking when enumerating many solutions.
+type DancingLinks struct{}
+
+func (DancingLinks) Name() string {
+	return "dlx"
+}
+
+func (DancingLinks) Solve(ctx context.Context, b *Board) (*Board, error) {
+	b, err := propagated(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var solved *Board
+
+	d := newDancingLinks(ctx, b)
+	d.search(func(options []int) bool {
+		solved = boardFromOptions(b, options)
+		return false
+	})
+
+	if solved != nil {
+		return solved, nil
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return nil, ErrUnsolvable
+}
+
+func (DancingLinks) CountSolutions(ctx context.Context, b *Board, limit int) (int, error) {
+	b, err := propagated(b)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	d := newDancingLinks(ctx, b)
+	d.search(func([]int) bool {
+		count++
+		return count != limit
+	})
+	return count, d.err
+}
+
+// dancingLinks is the sparse exact cover matrix of a board. Node 0 is the root,
+// nodes 1 to numConstraints are the column headers and the following nodes are
+// the matrix's ones, four per option.
+type dancingLinks struct {
+	ctx context.Context
+	// err records why the search was interrupted, if it was.
+	err error
+
+	left, right, up, down []int
+
+	// column holds the column header of each node.
+	column []int
+	// option holds the option of each node, as square*numDigits + value-1.
+	option []int
+	// size holds the number of nodes in each column, indexed by header.
+	size []int
+
+	solution []int
+}
+
+// newDancingLinks builds the matrix of a board. Only the possible values of
+// each square become options, so the board's constraint propagation carries
+// over to the search.
+func newDancingLinks(ctx context.Context, b *Board) *dancingLinks {
+	n := 1 + numConstraints + 4*numOptions
+	d := &dancingLinks{
+		ctx:      ctx,
+		left:     make([]int, n),
+		right:    make([]int, n),
+		up:       make([]int, n),
+		down:     make([]int, n),
+		column:   make([]int, n),
+		option:   make([]int, n),
+		size:     make([]int, 1+numConstraints),
+		solution: make([]int, 0, numSquares),
+	}
+
+	for i := range 1 + numConstraints {
+		d.left[i], d.right[i] = i-1, i+1
+		d.up[i], d.down[i] = i, i
+		d.column[i] = i
+	}
+	d.left[0], d.right[numConstraints] = numConstraints, 0
+
+	node := 1 + numConstraints
+	for square, mask := range b.squares {
+		row, column := square/numColumns, square%numColumns
+		box := squareUnits[square][2] - 2*numDigits
+
+		for ; mask != 0; mask &= mask - 1 {
+			digit := bits.TrailingZeros16(mask)
+			columns := [4]int{
+				1 + square,
+				1 + numSquares + row*numDigits + digit,
+				1 + 2*numSquares + column*numDigits + digit,
+				1 + 3*numSquares + box*numDigits + digit,
+			}
+
+			first := node
+			for _, c := range columns {
+				d.column[node] = c
+				d.option[node] = square*numDigits + digit
+
+				d.up[node], d.down[node] = d.up[c], c
+				d.down[d.up[c]], d.up[c] = node, node
+				d.size[c]++
+
+				d.left[node], d.right[node] = node-1, node+1
+				node++
+			}
+			d.left[first], d.right[node-1] = node-1, first
+		}
+	}
+	return d
+}
+
+func (d *dancingLinks) cover(c int) {
+	d.right[d.left[c]], d.left[d.right[c]] = d.right[c], d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]], d.up[d.down[j]] = d.down[j], d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+func (d *dancingLinks) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]], d.up[d.down[j]] = j, j
+		}
+	}
+	d.right[d.left[c]], d.left[d.right[c]] = c, c
+}
+
+// search calls yield with the options of each exact cover found. It returns
+// false if yield asked to stop the search or if the search was interrupted.
+func (d *dancingLinks) search(yield func([]int) bool) bool {
+	if err := d.ctx.Err(); err != nil {
+		d.err = err
+		return false
+	}
+	if d.right[0] == 0 {
+		return yield(d.solution)
+	}
+
+	// Branch on the constraint with the fewest options left.
+	c := d.right[0]
+	for i := d.right[c]; i != 0; i = d.right[i] {
+		if d.size[i] < d.size[c] {
+			c = i
+		}
+	}
+	if d.size[c] == 0 {
+		return true
+	}
+
+	d.cover(c)
+	defer d.uncover(c)
+
+	for r := d.down[c]; r != c; r = d.down[r] {
+		d.solution = append(d.solution, d.option[r])
+		for j := d.right[r]; j != r; j = d.right[j] {
+			d.cover(d.column[j])
+		}
+
+		more := d.search(yield)
+
+		for j := d.left[r]; j != r; j = d.left[j] {
+			d.uncover(d.column[j])
+		}
+		d.solution = d.solution[:len(d.solution)-1]
+
+		if !more {
+			return false
+		}
+	}
+	return true
+}
+
+// boardFromOptions returns a copy of the board with the values of the options.
+func boardFromOptions(b *Board, options []int) *Board {
+	board := b.Duplicate()
+	for _, option := range options {
+		board.squares[option/numDigits] = valueBit(option%numDigits + 1)
+	}
+	return board
+}
@@ -0,0 +1,65 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDancingLinks(t *testing.T) {
+	engine := sudoku.DancingLinks{}
+	ctx := context.Background()
+
+	t.Run("cross-check easy and hard problems", func(t *testing.T) {
+		for _, boardString := range append(easyProblems[:len(easyProblems):len(easyProblems)], hardProblems...) {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, err := engine.Solve(ctx, board)
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String(), boardString)
+			assert.Equal(t, board.PuzzleString(), solved.PuzzleString())
+
+			count, err := engine.CountSolutions(ctx, board, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count, boardString)
+		}
+	})
+
+	t.Run("multiple solutions", func(t *testing.T) {
+		board, err := sudoku.NewBoard(multipleSolutionsProblem)
+		assert.NoError(t, err)
+
+		count, err := engine.CountSolutions(ctx, board, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = engine.CountSolutions(ctx, board, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("count stops at the limit", func(t *testing.T) {
+		board, err := sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		count, err := engine.CountSolutions(ctx, board, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, count)
+	})
+
+	t.Run("no solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		solved, err := engine.Solve(ctx, board)
+		assert.ErrorIs(t, err, sudoku.ErrUnsolvable)
+		assert.Nil(t, solved)
+
+		count, err := engine.CountSolutions(ctx, board, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
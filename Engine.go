@@ -0,0 +1,72 @@
+package sudoku
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+var ErrUnknownEngine = fmt.Errorf("unknown solver engine")
+
+// SolverEngine is a sudoku solving algorithm.
+type SolverEngine interface {
+	// Name returns the name the engine is registered under.
+	Name() string
+	// Solve returns the first solution found for the board. It returns
+	// ErrContradiction if the board breaks the rules of sudoku, ErrUnsolvable
+	// if it has no solution, or the context's error if ctx is done first.
+	Solve(ctx context.Context, b *Board) (*Board, error)
+	// CountSolutions returns the number of solutions of the board, stopping
+	// once limit solutions have been found. A limit of zero or less counts
+	// them all. If ctx is done first, it returns the solutions counted so far
+	// along with the context's error.
+	CountSolutions(ctx context.Context, b *Board, limit int) (int, error)
+}
+
+// DefaultEngine is the engine used when none is specified.
+var DefaultEngine SolverEngine = Backtracking{}
+
+var engines = []SolverEngine{
+	Backtracking{},
+	DancingLinks{},
+	Parallel{},
+}
+
+// EngineNames returns the names of the built-in engines.
+func EngineNames() []string {
+	names := make([]string, 0, len(engines))
+	for _, e := range engines {
+		names = append(names, e.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EngineByName returns the built-in engine with the given name.
+func EngineByName(name string) (SolverEngine, error) {
+	for _, e := range engines {
+		if e.Name() == name {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownEngine, name)
+}
+
+// Backtracking solves boards with a depth-first search, guessing the value of
+// the square with the fewest possible values and propagating constraints.
+type Backtracking struct {
+	// Options bounds the work done by each search.
+	Options SolveOptions
+}
+
+func (Backtracking) Name() string {
+	return "backtracking"
+}
+
+func (e Backtracking) Solve(ctx context.Context, b *Board) (*Board, error) {
+	return SolveContext(ctx, b, e.Options)
+}
+
+func (e Backtracking) CountSolutions(ctx context.Context, b *Board, limit int) (int, error) {
+	return countSolutions(ctx, b, limit, e.Options)
+}
@@ -0,0 +1,105 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngines(t *testing.T) {
+	t.Run("look up engines by name", func(t *testing.T) {
+		assert.Equal(t, []string{"backtracking", "dlx", "parallel"}, sudoku.EngineNames())
+
+		for _, name := range sudoku.EngineNames() {
+			engine, err := sudoku.EngineByName(name)
+			assert.NoError(t, err)
+			assert.Equal(t, name, engine.Name())
+		}
+
+		_, err := sudoku.EngineByName("sat")
+		assert.ErrorIs(t, err, sudoku.ErrUnknownEngine)
+
+		assert.Equal(t, "backtracking", sudoku.DefaultEngine.Name())
+	})
+
+	for _, name := range sudoku.EngineNames() {
+		engine, err := sudoku.EngineByName(name)
+		assert.NoError(t, err)
+
+		t.Run(name, func(t *testing.T) {
+			t.Run("solve easy problems", func(t *testing.T) {
+				for _, boardString := range easyProblems {
+					board, err := sudoku.NewBoard(boardString)
+					assert.NoError(t, err)
+
+					solved, err := engine.Solve(context.Background(), board)
+					assert.NoError(t, err)
+					assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+				}
+			})
+
+			t.Run("no solution", func(t *testing.T) {
+				board, err := sudoku.NewBoard(noSolutionProblem)
+				assert.NoError(t, err)
+
+				_, err = engine.Solve(context.Background(), board)
+				assert.ErrorIs(t, err, sudoku.ErrUnsolvable)
+			})
+
+			t.Run("contradiction", func(t *testing.T) {
+				board := contradictoryBoard(t)
+
+				_, err := engine.Solve(context.Background(), board)
+				assert.ErrorIs(t, err, sudoku.ErrContradiction)
+
+				_, err = engine.CountSolutions(context.Background(), board, 0)
+				assert.ErrorIs(t, err, sudoku.ErrContradiction)
+			})
+
+			t.Run("cancelled context", func(t *testing.T) {
+				board, err := sudoku.NewBoard("")
+				assert.NoError(t, err)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err = engine.Solve(ctx, board)
+				assert.ErrorIs(t, err, context.Canceled)
+
+				_, err = engine.CountSolutions(ctx, board, 0)
+				assert.ErrorIs(t, err, context.Canceled)
+			})
+		})
+	}
+}
+
+func BenchmarkEngines(b *testing.B) {
+	boards := make([]*sudoku.Board, len(hardProblems))
+	for i, boardString := range hardProblems {
+		board, err := sudoku.NewBoard(boardString)
+		if err != nil {
+			b.Fatal(err)
+		}
+		boards[i] = board
+	}
+
+	for _, name := range sudoku.EngineNames() {
+		engine, err := sudoku.EngineByName(name)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				for _, board := range boards {
+					count, err := engine.CountSolutions(context.Background(), board, 0)
+					if err != nil || count != 1 {
+						b.Fatal("expected a unique solution")
+					}
+				}
+			}
+		})
+	}
+}
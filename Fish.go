@@ -0,0 +1,183 @@
+package sudoku
+
+import (
+	"math/bits"
+	"slices"
+)
+
+var fishNames = map[int]string{2: "X-Wing", 3: "Swordfish", 4: "Jellyfish"}
+
+// fish rules out a value from n cover lines outside n base lines, when the
+// value's candidates in the base lines all lie in the cover lines. Base lines
+// are rows and cover lines columns, or the other way around.
+func fish(n int) technique {
+	return technique{name: fishNames[n], find: func(g *grid) *Step {
+		return findFish(g, n, false)
+	}}
+}
+
+// finnedFish is like fish, except that some candidates of the base lines, the
+// fins, lie outside the cover lines but within a single box. Either a fin is
+// the value, or the fish is, so the value is only ruled out from the squares
+// of the cover lines that are in the fins' box. A sashimi fish is a finned
+// fish that leaves a base line with a single candidate without its fins.
+func finnedFish(n int) technique {
+	return technique{name: "Finned " + fishNames[n], find: func(g *grid) *Step {
+		return findFish(g, n, true)
+	}}
+}
+
+func findFish(g *grid, n int, finned bool) *Step {
+	for value := 1; value <= numDigits; value++ {
+		for _, base := range [][]int{rowUnits(), columnUnits()} {
+			// The cover line of position i of a base line.
+			cover := func(i int) int {
+				return (1-base[0]/numDigits)*numDigits + i
+			}
+
+			var lines []int
+			for _, u := range base {
+				count := bits.OnesCount16(g.positions(u, value))
+				// The fins of a finned fish lie in a box, so within
+				// boxSize positions.
+				if count >= 2 && count <= n || finned && count >= 1 && count <= n+boxSize {
+					lines = append(lines, u)
+				}
+			}
+
+			var found *Step
+			combinations(lines, n, func(baseLines []int) bool {
+				var union uint16
+				for _, u := range baseLines {
+					union |= g.positions(u, value)
+				}
+
+				if !finned {
+					if bits.OnesCount16(union) == n {
+						found = fishStep(g, value, baseLines, union, cover, -1)
+					}
+					return found == nil
+				}
+				if count := bits.OnesCount16(union); count <= n || count > n+boxSize {
+					return true
+				}
+
+				combinations(maskIndices(union), n, func(coverLines []int) bool {
+					var covered uint16
+					for _, i := range coverLines {
+						covered |= 1 << i
+					}
+					if box, ok := finBox(g, value, baseLines, covered); ok {
+						found = fishStep(g, value, baseLines, covered, cover, box)
+					}
+					return found == nil
+				})
+				return found == nil
+			})
+			if found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// finBox returns the box holding the candidates of the base lines outside the
+// covered positions, if they all lie in one. Each base line must keep a
+// candidate in the covered positions.
+func finBox(g *grid, value int, baseLines []int, covered uint16) (int, bool) {
+	box := -1
+	for _, u := range baseLines {
+		positions := g.positions(u, value)
+		if positions&covered == 0 {
+			return 0, false
+		}
+
+		for fins := positions &^ covered; fins != 0; fins &= fins - 1 {
+			b := squareUnits[unitSquares[u][bits.TrailingZeros16(fins)]][2]
+			if box != -1 && b != box {
+				return 0, false
+			}
+			box = b
+		}
+	}
+	return box, box != -1
+}
+
+// fishStep returns the step of a fish over the base lines and the covered
+// positions. If box is not -1, the fish is finned and eliminations are
+// restricted to the box.
+func fishStep(g *grid, value int, baseLines []int, covered uint16, cover func(int) int, box int) *Step {
+	step := &Step{Units: unitsOf(baseLines...)}
+	var squares, fins, targets []int
+	sashimi := false
+	for _, u := range baseLines {
+		positions := g.positions(u, value)
+		sashimi = sashimi || bits.OnesCount16(positions&covered) == 1
+		for ; positions != 0; positions &= positions - 1 {
+			i := bits.TrailingZeros16(positions)
+			squares = append(squares, unitSquares[u][i])
+			if covered&(1<<i) == 0 {
+				fins = append(fins, unitSquares[u][i])
+			}
+		}
+	}
+
+	for _, i := range maskIndices(covered) {
+		c := cover(i)
+		step.Units = append(step.Units, unitAt(c))
+		for _, s := range unitSquares[c] {
+			if !slices.Contains(baseLines, squareUnits[s][baseLines[0]/numDigits]) && (box == -1 || squareUnits[s][2] == box) {
+				targets = append(targets, s)
+			}
+		}
+	}
+	g.eliminate(step, targets, valueBit(value))
+
+	if box != -1 {
+		step.Technique = "Finned " + fishNames[len(baseLines)]
+		if sashimi {
+			step.Technique = "Sashimi " + fishNames[len(baseLines)]
+		}
+	}
+	slices.Sort(squares)
+	step = newStep(step, squares, valueBit(value))
+	if step == nil {
+		return nil
+	}
+
+	n := len(baseLines)
+	bases, covers := joinUnits(step.Units[:n]), joinUnits(step.Units[n:])
+	if box == -1 {
+		return step.because("In %s, %d can only go in %s", bases, value, covers)
+	}
+	return step.because("In %s, %d can only go in %s, unless one of the fins %v is %d; either way, %d cannot go in the squares of %s in %v",
+		bases, value, covers, squareList(fins), value, value, covers, unitAt(box))
+}
+
+// rowUnits and columnUnits return the indices of the row and column units.
+func rowUnits() []int {
+	return unitRange(0)
+}
+
+func columnUnits() []int {
+	return unitRange(numDigits)
+}
+
+func unitRange(first int) []int {
+	units := make([]int, numDigits)
+	for i := range units {
+		units[i] = first + i
+	}
+	return units
+}
+
+// maskIndices returns the indices of the bits set in a mask, in increasing
+// order.
+func maskIndices(mask uint16) []int {
+	indices := make([]int, 0, bits.OnesCount16(mask))
+	for ; mask != 0; mask &= mask - 1 {
+		indices = append(indices, bits.TrailingZeros16(mask))
+	}
+	return indices
+}
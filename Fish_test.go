@@ -0,0 +1,78 @@
+package sudoku_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFish(t *testing.T) {
+	t.Run("jellyfish", func(t *testing.T) {
+		// In rows 1, 2, 4 and 7, 1 can only go in columns 1, 2, 4 and 7.
+		rows, columns := []int{0, 1, 3, 6}, []int{0, 1, 3, 6}
+		candidates := map[sudoku.Square][]int{}
+		for _, row := range rows {
+			for _, column := range []int{2, 4, 5, 7, 8} {
+				candidates[sudoku.Square{Row: row, Column: column}] = []int{2, 3, 4, 5, 6, 7, 8, 9}
+			}
+		}
+		board := candidateBoard(t, candidates)
+
+		for _, name := range []string{"X-Wing", "Finned X-Wing", "Swordfish", "Finned Swordfish"} {
+			assert.Nil(t, findStep(t, name, board), name)
+		}
+
+		step := findStep(t, "Jellyfish", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Jellyfish", step.Technique)
+		assert.Equal(t, []int{1}, step.Values)
+		assert.Len(t, step.Squares, 16)
+
+		var units []string
+		for _, u := range step.Units {
+			units = append(units, u.String())
+		}
+		assert.Equal(t, []string{"row 1", "row 2", "row 4", "row 7", "column 1", "column 2", "column 4", "column 7"}, units)
+
+		var eliminations []sudoku.Candidate
+		for _, row := range []int{2, 4, 5, 7, 8} {
+			for _, column := range columns {
+				eliminations = append(eliminations, sudoku.Candidate{Square: sudoku.Square{Row: row, Column: column}, Value: 1})
+			}
+		}
+		assert.Equal(t, eliminations, step.Eliminations)
+	})
+
+	for _, tc := range []struct {
+		name      string
+		technique string
+		row5      []int
+	}{
+		{"finned x-wing", "Finned X-Wing", []int{1, 7, 8}},
+		{"sashimi x-wing", "Sashimi X-Wing", []int{1, 6, 8}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// 1 can only go in columns 2 and 8 of row 1, and in the given
+			// columns of row 5, where the fins lie in box 6.
+			candidates := map[sudoku.Square][]int{}
+			for column := range 9 {
+				for row, columns := range map[int][]int{0: {1, 7}, 4: tc.row5} {
+					if !slices.Contains(columns, column) {
+						candidates[sudoku.Square{Row: row, Column: column}] = []int{2, 3, 4, 5, 6, 7, 8, 9}
+					}
+				}
+			}
+			board := candidateBoard(t, candidates)
+
+			step := findStep(t, "Finned X-Wing", board)
+			assert.NotNil(t, step)
+			assert.Equal(t, tc.technique, step.Technique)
+			assert.Equal(t, []sudoku.Candidate{
+				{Square: sudoku.Square{Row: 3, Column: 7}, Value: 1},
+				{Square: sudoku.Square{Row: 5, Column: 7}, Value: 1},
+			}, step.Eliminations)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package sudoku
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Forcing chains try every way a constraint can be met: the values of a
+// square, the squares of a unit where a value can go, or a candidate being
+// the value or not. Each branch is propagated on a copy of the board, and
+// whatever holds in every branch that does not hit a contradiction holds on
+// the board.
+
+// cellForcingChain tries every candidate of a square.
+var cellForcingChain = technique{name: "Cell Forcing Chain", find: func(g *grid) *Step {
+	base, ok := g.forcingBoard()
+	if !ok {
+		return nil
+	}
+
+	for square := range numSquares {
+		mask := base.squares[square]
+		if n := bits.OnesCount16(mask); g.candidates[square] == 0 || n < 2 || n > 3 {
+			continue
+		}
+
+		var branches []*Board
+		for _, value := range maskValues(mask) {
+			branches = append(branches, forcingBranch(base, func(b *Board) bool {
+				return b.assign(square, value)
+			}))
+		}
+		if step := g.forcingStep(base, branches, &Step{}, []int{square}, mask); step != nil {
+			return step.because("Whichever of %v %v holds without a contradiction, the same follows", valueMask(mask), squareAt(square))
+		}
+	}
+	return nil
+}}
+
+// unitForcingChain tries every square of a unit where a value can go.
+var unitForcingChain = technique{name: "Unit Forcing Chain", find: func(g *grid) *Step {
+	base, ok := g.forcingBoard()
+	if !ok {
+		return nil
+	}
+
+	for u := range numUnits {
+		for value := 1; value <= numDigits; value++ {
+			var squares []int
+			for _, s := range unitSquares[u] {
+				if g.candidates[s] != 0 && base.countPossible(s) > 1 && base.valuePossible(s, value) {
+					squares = append(squares, s)
+				}
+			}
+			if len(squares) < 2 || len(squares) > 3 {
+				continue
+			}
+
+			var branches []*Board
+			for _, s := range squares {
+				branches = append(branches, forcingBranch(base, func(b *Board) bool {
+					return b.assign(s, value)
+				}))
+			}
+			if step := g.forcingStep(base, branches, &Step{Units: unitsOf(u)}, squares, valueBit(value)); step != nil {
+				return step.because("Wherever %d goes in %v without a contradiction, among %v, the same follows", value, unitAt(u), squareList(squares))
+			}
+		}
+	}
+	return nil
+}}
+
+// digitForcingChain tries a candidate being the value, and not being it.
+var digitForcingChain = technique{name: "Digit Forcing Chain", find: func(g *grid) *Step {
+	base, ok := g.forcingBoard()
+	if !ok {
+		return nil
+	}
+
+	for square := range numSquares {
+		if g.candidates[square] == 0 || base.countPossible(square) < 2 {
+			continue
+		}
+
+		for _, value := range maskValues(base.squares[square]) {
+			branches := []*Board{
+				forcingBranch(base, func(b *Board) bool {
+					return b.assign(square, value)
+				}),
+				forcingBranch(base, func(b *Board) bool {
+					return b.eliminateSquare(square, valueBit(value))
+				}),
+			}
+			if step := g.forcingStep(base, branches, &Step{}, []int{square}, valueBit(value)); step != nil {
+				return step.because("Whether %v holds %d or not, the same follows unless there is a contradiction", squareAt(square), value)
+			}
+		}
+	}
+	return nil
+}}
+
+// forcingBoard returns a copy of the board with the candidates of the grid,
+// propagated. It returns false if propagation hits a contradiction.
+func (g *grid) forcingBoard() (*Board, bool) {
+	b := g.board.Duplicate()
+	b.raw = false
+	for i, mask := range g.candidates {
+		if mask != 0 {
+			b.squares[i] = mask
+		}
+	}
+	return b, propagateSingles(b)
+}
+
+// forcingBranch returns a propagated copy of the base board after a change,
+// or nil if the branch hits a contradiction.
+func forcingBranch(base *Board, change func(b *Board) bool) *Board {
+	b := base.Duplicate()
+	if !change(b) || !propagateSingles(b) {
+		return nil
+	}
+	return b
+}
+
+// propagateSingles propagates the values of the board, and places the values
+// having a single possible square in a unit, until nothing changes. It
+// returns false on a contradiction.
+func propagateSingles(b *Board) bool {
+	if !b.propagate() {
+		return false
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for u := range numUnits {
+			for value := 1; value <= numDigits; value++ {
+				var squares []int
+				for _, s := range unitSquares[u] {
+					if b.valuePossible(s, value) {
+						squares = append(squares, s)
+					}
+				}
+
+				switch {
+				case len(squares) == 0:
+					return false
+				case len(squares) == 1 && b.countPossible(squares[0]) > 1:
+					if !b.assign(squares[0], value) {
+						return false
+					}
+					changed = true
+				}
+			}
+		}
+	}
+	return true
+}
+
+// forcingStep returns the step of the placements and eliminations made by
+// every branch not hitting a contradiction, but not by the base board.
+func (g *grid) forcingStep(base *Board, branches []*Board, step *Step, squares []int, values uint16) *Step {
+	branches = slices.DeleteFunc(branches, func(b *Board) bool { return b == nil })
+	if len(branches) == 0 {
+		return nil
+	}
+
+	for s := range numSquares {
+		if g.candidates[s] == 0 || base.countPossible(s) < 2 {
+			continue
+		}
+
+		var union uint16
+		for _, b := range branches {
+			union |= b.squares[s]
+		}
+		if bits.OnesCount16(union) == 1 {
+			step.Placements = append(step.Placements, Candidate{Square: squareAt(s), Value: bits.TrailingZeros16(union) + 1})
+			continue
+		}
+		g.eliminate(step, []int{s}, base.squares[s]&^union)
+	}
+	return newStep(step, squares, values)
+}
@@ -0,0 +1,14 @@
+package sudoku_test
+
+import (
+	"testing"
+)
+
+func TestForcingChains(t *testing.T) {
+	boards := stuckBoards(t)
+	for _, name := range []string{"Cell Forcing Chain", "Unit Forcing Chain", "Digit Forcing Chain"} {
+		t.Run(name, func(t *testing.T) {
+			assertTechniqueOnStuckBoards(t, name, boards)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package sudoku
+
+import (
+	"fmt"
+	"slices"
+)
+
+var ErrWrongEntry = fmt.Errorf("value does not match the solution")
+
+// HintLevel is how much of a step a hint discloses.
+type HintLevel int
+
+const (
+	// HintRegion only tells the units to look at.
+	HintRegion HintLevel = iota
+	// HintTechnique tells the technique to use as well.
+	HintTechnique
+	// HintFull tells the whole step.
+	HintFull
+)
+
+// NextHint returns the step the default logical solver would take next on the
+// board, found by the simplest technique that applies. See
+// LogicalSolver.NextHint.
+func NextHint(b *Board) (*Step, error) {
+	return NewLogicalSolver().NextHint(b)
+}
+
+// NextHint returns the step the solver would take next on the board. Values
+// set with SetValue and candidates removed with RemoveCandidate are checked
+// against the unique solution of the givens first: the first wrong one is
+// returned as a *BoardError wrapping ErrWrongEntry, whose Value is the value
+// set, or the solution's value for a removed candidate. It returns
+// ErrUnsolvable or ErrMultipleSolutions if the givens do not have a unique
+// solution, ErrStuck if no technique applies, and nil if the board is already
+// solved.
+func (s *LogicalSolver) NextHint(b *Board) (*Step, error) {
+	wrong, err := wrongEntries(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrong) > 0 {
+		return nil, &BoardError{Err: ErrWrongEntry, Row: wrong[0].Row, Column: wrong[0].Column, Value: wrong[0].Value}
+	}
+
+	board := b.Duplicate()
+	if !board.clearSolvedValues() {
+		return nil, ErrContradiction
+	}
+	if board.isLogicallySolved() {
+		return nil, nil
+	}
+
+	step := s.next(board)
+	if step == nil {
+		return nil, ErrStuck
+	}
+	return step, nil
+}
+
+// WrongEntries returns the squares, in row-major order, whose values set with
+// SetValue do not match the unique solution of the givens, or whose
+// candidates removed with RemoveCandidate include the solution's value. It
+// returns ErrUnsolvable or ErrMultipleSolutions if there is no such solution.
+func WrongEntries(b *Board) ([]Square, error) {
+	wrong, err := wrongEntries(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var squares []Square
+	for _, c := range wrong {
+		squares = append(squares, c.Square)
+	}
+	return squares, nil
+}
+
+// wrongEntries is like WrongEntries, but returns the value set in each square,
+// or the solution's value if it was removed.
+func wrongEntries(b *Board) ([]Candidate, error) {
+	puzzle, err := NewBoard(b.PuzzleString())
+	if err != nil {
+		return nil, err
+	}
+	solution, err := Solve(puzzle)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrong []Candidate
+	for i, value := range b.entries {
+		switch expected := solution.value(i); {
+		case value != EmptySquare && int(value) != expected:
+			wrong = append(wrong, Candidate{Square: squareAt(i), Value: int(value)})
+		case b.removed[i]&valueBit(expected) != 0:
+			wrong = append(wrong, Candidate{Square: squareAt(i), Value: expected})
+		}
+	}
+	return wrong, nil
+}
+
+// Disclose returns the part of the step a hint of the given level tells. The
+// region is the units of the step, or the boxes of its squares if it has
+// none.
+func (s Step) Disclose(level HintLevel) Step {
+	if level >= HintFull {
+		return s
+	}
+
+	region := s.Units
+	if len(region) == 0 {
+		var boxes []int
+		for _, square := range s.Squares {
+			box := squareUnits[squareIndex(square.Row, square.Column)][2]
+			if !slices.Contains(boxes, box) {
+				boxes = append(boxes, box)
+			}
+		}
+		slices.Sort(boxes)
+		region = unitsOf(boxes...)
+	}
+
+	hint := Step{Units: region}
+	if level == HintTechnique {
+		hint.Technique = s.Technique
+		hint.Explanation = fmt.Sprintf("Use the %s technique in %s.", s.Technique, joinUnits(region))
+	} else {
+		hint.Explanation = fmt.Sprintf("Look at %s.", joinUnits(region))
+	}
+	return hint
+}
@@ -0,0 +1,165 @@
+package sudoku_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHint(t *testing.T) {
+	t.Run("simplest step", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		step, err := sudoku.NextHint(board)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hidden Single", step.Technique)
+		assert.Equal(t, []sudoku.Candidate{{Square: sudoku.Square{Row: 5, Column: 1}, Value: 4}}, step.Placements)
+
+		// Once the hint is followed, the next one moves on.
+		assert.NoError(t, board.SetValue(5, 1, 4))
+		next, err := sudoku.NextHint(board)
+		assert.NoError(t, err)
+		assert.NotEqual(t, step.Placements, next.Placements)
+	})
+
+	t.Run("follow hints to the solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[1])
+		assert.NoError(t, err)
+
+		for {
+			step, err := sudoku.NextHint(board)
+			assert.NoError(t, err)
+			if step == nil {
+				break
+			}
+			for _, c := range step.Eliminations {
+				assert.NoError(t, board.RemoveCandidate(c.Row, c.Column, c.Value))
+			}
+			for _, c := range step.Placements {
+				assert.NoError(t, board.SetValue(c.Row, c.Column, c.Value))
+			}
+		}
+		assert.True(t, board.IsSolved())
+	})
+
+	t.Run("wrong entries", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		solution, err := sudoku.Solve(board)
+		assert.NoError(t, err)
+
+		// r1c2 can hold 1, 6, 7 or 9, and the solution holds 1.
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		wrong, err := sudoku.WrongEntries(board)
+		assert.NoError(t, err)
+		assert.Empty(t, wrong)
+
+		// Set a wrong value in the first square with several candidates.
+		var square sudoku.Square
+		var value int
+		for i := 0; value == 0; i++ {
+			square = sudoku.Square{Row: i / 9, Column: i % 9}
+			candidates, err := board.Candidates(square.Row, square.Column)
+			assert.NoError(t, err)
+			expected, err := solution.GetValue(square.Row, square.Column)
+			assert.NoError(t, err)
+			for _, c := range candidates {
+				if len(candidates) > 1 && c != expected {
+					value = c
+					break
+				}
+			}
+		}
+		assert.NoError(t, board.SetValue(square.Row, square.Column, value))
+
+		wrong, err = sudoku.WrongEntries(board)
+		assert.NoError(t, err)
+		assert.Equal(t, []sudoku.Square{square}, wrong)
+
+		step, err := sudoku.NextHint(board)
+		assert.Nil(t, step)
+		assert.ErrorIs(t, err, sudoku.ErrWrongEntry)
+		var boardErr *sudoku.BoardError
+		if assert.True(t, errors.As(err, &boardErr)) {
+			assert.Equal(t, square, sudoku.Square{Row: boardErr.Row, Column: boardErr.Column})
+			assert.Equal(t, value, boardErr.Value)
+		}
+	})
+
+	t.Run("wrong removals", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		// r7c2 holds 8 in the solution.
+		square := sudoku.Square{Row: 6, Column: 1}
+		assert.NoError(t, board.RemoveCandidate(square.Row, square.Column, 8))
+
+		wrong, err := sudoku.WrongEntries(board)
+		assert.NoError(t, err)
+		assert.Equal(t, []sudoku.Square{square}, wrong)
+
+		step, err := sudoku.NextHint(board)
+		assert.Nil(t, step)
+		assert.ErrorIs(t, err, sudoku.ErrWrongEntry)
+		var boardErr *sudoku.BoardError
+		if assert.True(t, errors.As(err, &boardErr)) {
+			assert.Equal(t, square, sudoku.Square{Row: boardErr.Row, Column: boardErr.Column})
+			assert.Equal(t, 8, boardErr.Value)
+		}
+	})
+
+	t.Run("puzzles without a unique solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(multipleSolutionsProblem)
+		assert.NoError(t, err)
+		_, err = sudoku.NextHint(board)
+		assert.ErrorIs(t, err, sudoku.ErrMultipleSolutions)
+
+		board, err = sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+		_, err = sudoku.NextHint(board)
+		assert.ErrorIs(t, err, sudoku.ErrUnsolvable)
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		none := &sudoku.LogicalSolver{}
+		_, err = none.NextHint(board)
+		assert.ErrorIs(t, err, sudoku.ErrStuck)
+	})
+}
+
+func TestDisclose(t *testing.T) {
+	board, err := sudoku.NewBoard(hardProblems[0])
+	assert.NoError(t, err)
+	step, err := sudoku.NextHint(board)
+	assert.NoError(t, err)
+
+	region := step.Disclose(sudoku.HintRegion)
+	assert.Equal(t, "Look at box 4.", region.Explanation)
+	assert.Empty(t, region.Technique)
+	assert.Empty(t, region.Squares)
+	assert.Empty(t, region.Placements)
+
+	technique := step.Disclose(sudoku.HintTechnique)
+	assert.Equal(t, "Use the Hidden Single technique in box 4.", technique.Explanation)
+	assert.Equal(t, "Hidden Single", technique.Technique)
+	assert.Equal(t, step.Units, technique.Units)
+	assert.Empty(t, technique.Placements)
+
+	assert.Equal(t, *step, step.Disclose(sudoku.HintFull))
+
+	t.Run("region of a step without units", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			{Row: 0, Column: 0}: {1, 2}, {Row: 0, Column: 4}: {1, 3}, {Row: 4, Column: 0}: {2, 3},
+		})
+		step := findStep(t, "XY-Wing", board)
+
+		region := step.Disclose(sudoku.HintRegion)
+		assert.Equal(t, "Look at box 1, box 2, box 4.", region.Explanation)
+	})
+}
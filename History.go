@@ -0,0 +1,88 @@
+package sudoku
+
+import "fmt"
+
+var (
+	ErrHistoryDisabled = fmt.Errorf("move history is not enabled")
+	ErrNothingToUndo   = fmt.Errorf("nothing to undo")
+	ErrNothingToRedo   = fmt.Errorf("nothing to redo")
+)
+
+// history holds the states of a board before each recorded move, and the
+// states undone since the last move.
+type history struct {
+	undo []Board
+	redo []Board
+}
+
+// EnableHistory starts recording the moves made with SetValue, ClearValue,
+// RemoveCandidate and ResetCandidates, so that they can be undone. Enabling
+// it again has no effect.
+func (b *Board) EnableHistory() {
+	if b.history == nil {
+		b.history = &history{}
+	}
+}
+
+// state returns a copy of the board without its history.
+func (b *Board) state() Board {
+	state := *b
+	state.history = nil
+	return state
+}
+
+// record saves the state of the board before a move, and returns a function
+// to call once the move is made. Moves leaving the board unchanged, such as
+// rejected ones, are not recorded. It does nothing if history is disabled.
+func (b *Board) record() func() {
+	if b.history == nil {
+		return func() {}
+	}
+
+	before := b.state()
+	return func() {
+		if b.state() != before {
+			b.history.undo = append(b.history.undo, before)
+			b.history.redo = nil
+		}
+	}
+}
+
+// restore replaces the state of the board, keeping its history.
+func (b *Board) restore(state Board) {
+	h := b.history
+	*b = state
+	b.history = h
+}
+
+// Undo reverts the last recorded move.
+func (b *Board) Undo() error {
+	if b.history == nil {
+		return ErrHistoryDisabled
+	}
+	h := b.history
+	if len(h.undo) == 0 {
+		return ErrNothingToUndo
+	}
+
+	h.redo = append(h.redo, b.state())
+	b.restore(h.undo[len(h.undo)-1])
+	h.undo = h.undo[:len(h.undo)-1]
+	return nil
+}
+
+// Redo makes the last move reverted by Undo again.
+func (b *Board) Redo() error {
+	if b.history == nil {
+		return ErrHistoryDisabled
+	}
+	h := b.history
+	if len(h.redo) == 0 {
+		return ErrNothingToRedo
+	}
+
+	h.undo = append(h.undo, b.state())
+	b.restore(h.redo[len(h.redo)-1])
+	h.redo = h.redo[:len(h.redo)-1]
+	return nil
+}
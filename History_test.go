@@ -0,0 +1,186 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClearValue(t *testing.T) {
+	t.Run("clearing a value restores the candidates", func(t *testing.T) {
+		want, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		board := want.Duplicate()
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		assert.NoError(t, board.SetValue(4, 0, 9))
+		assert.NotEqual(t, want.String(), board.String())
+
+		assert.NoError(t, board.ClearValue(0, 1))
+		assert.NoError(t, board.ClearValue(4, 0))
+		assert.Equal(t, want.String(), board.String())
+
+		for _, square := range []sudoku.Square{{Row: 0, Column: 1}, {Row: 0, Column: 2}, {Row: 4, Column: 0}} {
+			candidates, err := board.Candidates(square.Row, square.Column)
+			assert.NoError(t, err)
+			wantCandidates, err := want.Candidates(square.Row, square.Column)
+			assert.NoError(t, err)
+			assert.Equal(t, wantCandidates, candidates, square.String())
+		}
+	})
+
+	t.Run("other moves are kept", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		assert.NoError(t, board.RemoveCandidate(8, 8, 9))
+		assert.NoError(t, board.SetValue(4, 0, 9))
+		assert.NoError(t, board.ClearValue(4, 0))
+
+		value, err := board.GetValue(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		has, err := board.HasCandidate(8, 8, 9)
+		assert.NoError(t, err)
+		assert.False(t, has)
+
+		value, err = board.GetValue(4, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, sudoku.EmptySquare, value)
+	})
+
+	t.Run("givens cannot be changed", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, board.ClearValue(0, 0), sudoku.ErrGivenSquare)
+		assert.ErrorIs(t, board.SetValue(0, 0, 1), sudoku.ErrGivenSquare)
+		assert.NoError(t, board.SetValue(0, 0, 4))
+
+		assert.ErrorIs(t, board.ClearValue(9, 0), sudoku.ErrInvalidPosition)
+	})
+
+	t.Run("raw boards", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.NoError(t, board.SetValue(0, 1, 4))
+		assert.NoError(t, board.ClearValue(0, 1))
+		assert.Equal(t, hardProblems[0], board.String())
+	})
+}
+
+func TestHistory(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, board.Undo(), sudoku.ErrHistoryDisabled)
+		assert.ErrorIs(t, board.Redo(), sudoku.ErrHistoryDisabled)
+	})
+
+	t.Run("undo and redo moves", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+
+		var states []string
+		states = append(states, board.String())
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		states = append(states, board.String())
+		assert.NoError(t, board.RemoveCandidate(0, 2, 6))
+		states = append(states, board.String())
+		assert.NoError(t, board.ClearValue(0, 1))
+		states = append(states, board.String())
+
+		for i := len(states) - 2; i >= 0; i-- {
+			assert.NoError(t, board.Undo())
+			assert.Equal(t, states[i], board.String())
+		}
+		assert.ErrorIs(t, board.Undo(), sudoku.ErrNothingToUndo)
+
+		for i := 1; i < len(states); i++ {
+			assert.NoError(t, board.Redo())
+			assert.Equal(t, states[i], board.String())
+		}
+		assert.ErrorIs(t, board.Redo(), sudoku.ErrNothingToRedo)
+	})
+
+	t.Run("a new move discards the redo history", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		assert.NoError(t, board.Undo())
+		assert.NoError(t, board.SetValue(0, 1, 6))
+		assert.ErrorIs(t, board.Redo(), sudoku.ErrNothingToRedo)
+
+		value, err := board.GetValue(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, value)
+	})
+
+	t.Run("rejected moves are not recorded", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+
+		assert.ErrorIs(t, board.SetValue(0, 1, 4), sudoku.ErrDuplicateValue)
+		assert.ErrorIs(t, board.ClearValue(0, 0), sudoku.ErrGivenSquare)
+		assert.ErrorIs(t, board.Undo(), sudoku.ErrNothingToUndo)
+	})
+
+	t.Run("rejected removals are not recorded", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		assert.NoError(t, board.RemoveCandidate(6, 4, 4))
+		board.EnableHistory()
+
+		assert.ErrorIs(t, board.RemoveCandidate(6, 4, 5), sudoku.ErrContradiction)
+		assert.ErrorIs(t, board.Undo(), sudoku.ErrNothingToUndo)
+	})
+
+	t.Run("failed moves leave the board unchanged", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+
+		want := board.String()
+		// 9 is possible in r7c5, but propagating it hits a contradiction.
+		assert.ErrorIs(t, board.SetValue(6, 4, 9), sudoku.ErrDuplicateValue)
+		assert.Equal(t, want, board.String())
+		assert.True(t, board.IsValid())
+		assert.ErrorIs(t, board.Undo(), sudoku.ErrNothingToUndo)
+	})
+
+	t.Run("replace a value", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+
+		// r1c2 is 1 in the solution, and may be 6 as far as its peers know.
+		assert.NoError(t, board.SetValue(0, 1, 6))
+		want := board.String()
+		assert.NoError(t, board.SetValue(0, 1, 1))
+		value, err := board.GetValue(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		assert.NoError(t, board.Undo())
+		assert.Equal(t, want, board.String())
+	})
+
+	t.Run("duplicates do not share the history", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		board.EnableHistory()
+		assert.NoError(t, board.SetValue(0, 1, 1))
+
+		assert.ErrorIs(t, board.Duplicate().Undo(), sudoku.ErrHistoryDisabled)
+		assert.NoError(t, board.Undo())
+	})
+}
@@ -0,0 +1,374 @@
+package sudoku
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+var ErrStuck = fmt.Errorf("no technique applies")
+
+// Candidate is a possible value of a square.
+type Candidate struct {
+	Square
+	Value int `json:"value"`
+}
+
+func (c Candidate) String() string {
+	return fmt.Sprintf("%v=%d", c.Square, c.Value)
+}
+
+// Step is a deduction made by a technique: values placed in squares and
+// candidates ruled out.
+type Step struct {
+	// Technique is the name of the technique that found the step.
+	Technique string `json:"technique"`
+	// Units are the units the pattern lies in, if any.
+	Units []Unit `json:"units,omitempty"`
+	// Squares are the squares forming the pattern that justifies the step.
+	Squares []Square `json:"squares"`
+	// Values are the values the pattern is about.
+	Values []int `json:"values"`
+	// Chain holds the candidates of a chain, from its start, alternating
+	// strong and weak links. It is empty unless the pattern is a chain.
+	Chain []Candidate `json:"chain,omitempty"`
+
+	Placements   []Candidate `json:"placements,omitempty"`
+	Eliminations []Candidate `json:"eliminations,omitempty"`
+
+	// Explanation is a sentence explaining the step to a human.
+	Explanation string `json:"explanation"`
+}
+
+// Technique is a human-style solving technique.
+type Technique interface {
+	// Name returns the name of the technique.
+	Name() string
+	// Find returns a step the technique can make on the board, or nil if it
+	// does not apply. The board is not modified.
+	//
+	// Squares holding a given or a value set with SetValue are solved, and
+	// their values are not candidates of their peers. Other squares are
+	// unsolved, even if they have a single candidate left.
+	Find(b *Board) *Step
+}
+
+// LogicalSolver solves boards like a human would, repeatedly applying the
+// simplest technique that makes progress, without guessing.
+type LogicalSolver struct {
+	// Techniques are tried in order. After each step, the search for the next
+	// one starts again from the first technique.
+	Techniques []Technique
+	// NoUniqueness disables the techniques assuming the board has a unique
+	// solution, see AssumesUniqueness.
+	NoUniqueness bool
+}
+
+// NewLogicalSolver returns a solver using DefaultTechniques.
+func NewLogicalSolver() *LogicalSolver {
+	return &LogicalSolver{Techniques: DefaultTechniques()}
+}
+
+// DefaultTechniques returns the built-in techniques, from the simplest to the
+// most complex.
+func DefaultTechniques() []Technique {
+	return []Technique{
+		nakedSingle,
+		hiddenSingle,
+		pointing,
+		boxLineReduction,
+		nakedSubset(2),
+		hiddenSubset(2),
+		nakedSubset(3),
+		hiddenSubset(3),
+		fish(2),
+		finnedFish(2),
+		simpleColoring,
+		xyWing,
+		uniqueRectangle,
+		bugPlusOne,
+		nakedSubset(4),
+		hiddenSubset(4),
+		fish(3),
+		finnedFish(3),
+		xyzWing,
+		wWing,
+		hiddenUniqueRectangle,
+		fish(4),
+		finnedFish(4),
+		multiColoring,
+		xChain,
+		xyChain,
+		sueDeCoq,
+		alsXZ,
+		alsXYWing,
+		deathBlossom,
+		cellForcingChain,
+		unitForcingChain,
+		digitForcingChain,
+	}
+}
+
+// Solve applies techniques to a copy of the board until it is solved, and
+// returns it with the steps taken. Placed values are set as with SetValue. If
+// no technique applies before the board is solved, it returns the partially
+// solved board and ErrStuck. It returns ErrContradiction if the board, or a
+// step, breaks the rules of sudoku.
+func (s *LogicalSolver) Solve(b *Board) (*Board, []Step, error) {
+	board := b.Duplicate()
+	if !board.clearSolvedValues() {
+		return nil, nil, ErrContradiction
+	}
+
+	var steps []Step
+	for !board.isLogicallySolved() {
+		step := s.next(board)
+		if step == nil {
+			return board, steps, ErrStuck
+		}
+
+		steps = append(steps, *step)
+		if err := board.apply(step); err != nil {
+			return board, steps, err
+		}
+	}
+	return board, steps, nil
+}
+
+// next returns the first step found by the techniques, or nil.
+func (s *LogicalSolver) next(b *Board) *Step {
+	for _, t := range s.Techniques {
+		if s.NoUniqueness && AssumesUniqueness(t) {
+			continue
+		}
+		if step := t.Find(b); step != nil {
+			if step.Explanation == "" {
+				step.Explanation = explain(step)
+			}
+			return step
+		}
+	}
+	return nil
+}
+
+// isSolvedSquare reports whether a square holds a given or a value set.
+func (b *Board) isSolvedSquare(square int) bool {
+	return b.givens[square] != EmptySquare || b.entries[square] != EmptySquare
+}
+
+// solvedString returns the board string of the solved squares only, the
+// givens and the values set, in the same format as String.
+func (b *Board) solvedString() string {
+	var str strings.Builder
+
+	for i := range numSquares {
+		if b.isSolvedSquare(i) {
+			str.WriteByte(byte(b.value(i) + '0'))
+		} else {
+			str.WriteByte('.')
+		}
+	}
+	return str.String()
+}
+
+func (b *Board) isLogicallySolved() bool {
+	for i := range numSquares {
+		if !b.isSolvedSquare(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// clearSolvedValues removes the value of each solved square from the
+// candidates of its peers, without propagating further. It returns false if
+// this leaves a square without candidates.
+func (b *Board) clearSolvedValues() bool {
+	for i := range numSquares {
+		if !b.isSolvedSquare(i) {
+			continue
+		}
+		for _, p := range peerSquares[i] {
+			if b.squares[p] &^= b.squares[i]; b.squares[p] == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// apply makes the placements and eliminations of a step, without
+// propagating further.
+func (b *Board) apply(step *Step) error {
+	for _, c := range step.Eliminations {
+		square := squareIndex(c.Row, c.Column)
+		if b.squares[square] &^= valueBit(c.Value); b.squares[square] == 0 {
+			return valueError(ErrContradiction, square, c.Value)
+		}
+	}
+
+	for _, c := range step.Placements {
+		square := squareIndex(c.Row, c.Column)
+		if !b.valuePossible(square, c.Value) {
+			return valueError(ErrContradiction, square, c.Value)
+		}
+
+		b.squares[square] = valueBit(c.Value)
+		b.entries[square] = uint8(c.Value)
+		for _, p := range peerSquares[square] {
+			if b.squares[p] &^= valueBit(c.Value); b.squares[p] == 0 {
+				return valueError(ErrContradiction, p, c.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// technique is a built-in Technique.
+type technique struct {
+	name       string
+	uniqueness bool
+	find       func(g *grid) *Step
+}
+
+func (t technique) Name() string {
+	return t.name
+}
+
+func (t technique) AssumesUniqueness() bool {
+	return t.uniqueness
+}
+
+func (t technique) Find(b *Board) *Step {
+	step := t.find(newGrid(b))
+	if step != nil && step.Technique == "" {
+		step.Technique = t.name
+	}
+	if step != nil {
+		step.Explanation = explain(step)
+	}
+	return step
+}
+
+// grid is the view of a board that techniques work on.
+type grid struct {
+	// board is the board the grid is a view of.
+	board *Board
+	// candidates holds the candidates of the unsolved squares, and 0 for
+	// the solved ones.
+	candidates [numSquares]uint16
+	// solved holds the values of the solved squares of each unit.
+	solved [numUnits]uint16
+}
+
+func newGrid(b *Board) *grid {
+	g := &grid{board: b}
+	for i := range numSquares {
+		if b.isSolvedSquare(i) {
+			for _, u := range squareUnits[i] {
+				g.solved[u] |= b.squares[i]
+			}
+		}
+	}
+	for i := range numSquares {
+		if !b.isSolvedSquare(i) {
+			u := squareUnits[i]
+			g.candidates[i] = b.squares[i] &^ (g.solved[u[0]] | g.solved[u[1]] | g.solved[u[2]])
+		}
+	}
+	return g
+}
+
+// positions returns the positions within a unit of the squares having a value
+// as candidate, as a bitmask where bit i stands for the unit's i-th square.
+func (g *grid) positions(unit, value int) uint16 {
+	var mask uint16
+	for i, s := range unitSquares[unit] {
+		if g.candidates[s]&valueBit(value) != 0 {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// eliminate adds to the step the candidates of the squares among values.
+func (g *grid) eliminate(step *Step, squares []int, values uint16) {
+	for _, s := range squares {
+		for mask := g.candidates[s] & values; mask != 0; mask &= mask - 1 {
+			step.Eliminations = append(step.Eliminations, Candidate{Square: squareAt(s), Value: bits.TrailingZeros16(mask) + 1})
+		}
+	}
+}
+
+// newStep returns a step about squares and values. Eliminations are sorted
+// and deduplicated, and steps without placements or eliminations are
+// discarded.
+func newStep(step *Step, squares []int, values uint16) *Step {
+	if len(step.Placements) == 0 && len(step.Eliminations) == 0 {
+		return nil
+	}
+
+	for _, s := range squares {
+		step.Squares = append(step.Squares, squareAt(s))
+	}
+	step.Values = maskValues(values)
+
+	slices.SortFunc(step.Eliminations, compareCandidates)
+	step.Eliminations = slices.Compact(step.Eliminations)
+	return step
+}
+
+func compareCandidates(a, b Candidate) int {
+	return cmp.Or(
+		cmp.Compare(a.Row, b.Row),
+		cmp.Compare(a.Column, b.Column),
+		cmp.Compare(a.Value, b.Value),
+	)
+}
+
+// maskValues returns the values of a bitmask, in increasing order.
+func maskValues(mask uint16) []int {
+	values := make([]int, 0, bits.OnesCount16(mask))
+	for ; mask != 0; mask &= mask - 1 {
+		values = append(values, bits.TrailingZeros16(mask)+1)
+	}
+	return values
+}
+
+// unitsOf returns the units with the given indices.
+func unitsOf(indices ...int) []Unit {
+	units := make([]Unit, len(indices))
+	for i, u := range indices {
+		units[i] = unitAt(u)
+	}
+	return units
+}
+
+// sees reports whether two different squares share a unit.
+func sees(a, b int) bool {
+	ua, ub := squareUnits[a], squareUnits[b]
+	return a != b && (ua[0] == ub[0] || ua[1] == ub[1] || ua[2] == ub[2])
+}
+
+// combinations calls yield with every subset of size k of the items, in
+// lexicographic order, until yield returns false. The subset is reused
+// between calls.
+func combinations(items []int, k int, yield func([]int) bool) bool {
+	subset := make([]int, k)
+	var visit func(start, depth int) bool
+	visit = func(start, depth int) bool {
+		if depth == k {
+			return yield(subset)
+		}
+		for i := start; i <= len(items)-(k-depth); i++ {
+			subset[depth] = items[i]
+			if !visit(i+1, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	return visit(0, 0)
+}
@@ -0,0 +1,166 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSoundSteps checks that the steps taken on a board never place a value
+// other than the solution's, nor rule it out.
+func assertSoundSteps(t *testing.T, board *sudoku.Board, steps []sudoku.Step) {
+	t.Helper()
+
+	solution, err := sudoku.Solve(board)
+	assert.NoError(t, err)
+
+	for _, step := range steps {
+		for _, c := range step.Placements {
+			value, err := solution.GetValue(c.Row, c.Column)
+			assert.NoError(t, err)
+			assert.Equal(t, value, c.Value, "%s placed %v", step.Technique, c)
+		}
+		for _, c := range step.Eliminations {
+			value, err := solution.GetValue(c.Row, c.Column)
+			assert.NoError(t, err)
+			assert.NotEqual(t, value, c.Value, "%s ruled out %v", step.Technique, c)
+		}
+	}
+}
+
+// stuckBoards returns the boards a solver with the default techniques before
+// Sue de Coq, the simplest of the almost locked set and forcing chain ones,
+// gets stuck on, from the hard problems.
+func stuckBoards(t *testing.T) []*sudoku.Board {
+	t.Helper()
+
+	solver := &sudoku.LogicalSolver{}
+	for _, technique := range sudoku.DefaultTechniques() {
+		if technique.Name() == "Sue de Coq" {
+			break
+		}
+		solver.Techniques = append(solver.Techniques, technique)
+	}
+
+	var boards []*sudoku.Board
+	for _, boardString := range hardProblems {
+		board, err := sudoku.NewBoard(boardString)
+		assert.NoError(t, err)
+
+		if stuck, _, err := solver.Solve(board); err != nil {
+			assert.ErrorIs(t, err, sudoku.ErrStuck)
+			boards = append(boards, stuck)
+		}
+	}
+	return boards
+}
+
+// assertTechniqueOnStuckBoards checks that the named technique makes sound
+// steps on stuck boards.
+func assertTechniqueOnStuckBoards(t *testing.T, name string, boards []*sudoku.Board) {
+	t.Helper()
+
+	var found int
+	for _, board := range boards {
+		if step := findStep(t, name, board); step != nil {
+			assert.Equal(t, name, step.Technique)
+			assertSoundSteps(t, board, []sudoku.Step{*step})
+			found++
+		}
+	}
+	assert.NotZero(t, found, "%s never applies", name)
+}
+
+func TestLogicalSolver(t *testing.T) {
+	solver := sudoku.NewLogicalSolver()
+
+	t.Run("solve easy problems", func(t *testing.T) {
+		for _, boardString := range easyProblems {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, steps, err := solver.Solve(board)
+			assert.NoError(t, err)
+			assert.True(t, solved.IsSolved())
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+			assertSoundSteps(t, board, steps)
+		}
+	})
+
+	t.Run("solve hard problems", func(t *testing.T) {
+		used := map[string]bool{}
+		for _, boardString := range hardProblems {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, steps, err := solver.Solve(board)
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+			assertSoundSteps(t, board, steps)
+
+			for _, step := range steps {
+				used[step.Technique] = true
+			}
+		}
+
+		for _, name := range []string{
+			"Naked Single", "Hidden Single", "Pointing Pair", "Pointing Triple",
+			"Box/Line Reduction", "Naked Pair", "Hidden Pair", "Naked Triple", "Hidden Triple",
+			"X-Wing", "Finned X-Wing", "Sashimi X-Wing", "Swordfish", "Finned Swordfish",
+			"Simple Coloring", "Multi-Coloring", "XY-Wing", "XYZ-Wing", "W-Wing", "X-Chain", "XY-Chain",
+			"Unique Rectangle Type 2", "Unique Rectangle Type 3", "Unique Rectangle Type 4",
+			"Unique Rectangle Type 6", "Hidden Unique Rectangle",
+			"Sue de Coq", "ALS-XZ", "ALS-XY-Wing", "Cell Forcing Chain",
+		} {
+			assert.True(t, used[name], "%s was never used", name)
+		}
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		board, err := sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		solved, steps, err := solver.Solve(board)
+		assert.ErrorIs(t, err, sudoku.ErrStuck)
+		assert.Empty(t, steps)
+		assert.Equal(t, board.String(), solved.String())
+	})
+
+	t.Run("values set are solved squares", func(t *testing.T) {
+		board, err := sudoku.NewBoard(easyProblems[0])
+		assert.NoError(t, err)
+		solution := sudoku.Solver(board)
+
+		value, err := solution.GetValue(0, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, board.SetValue(0, 2, value))
+
+		_, steps, err := solver.Solve(board)
+		assert.NoError(t, err)
+		for _, step := range steps {
+			for _, c := range step.Placements {
+				assert.NotEqual(t, sudoku.Square{Row: 0, Column: 2}, c.Square)
+			}
+		}
+	})
+
+	t.Run("contradiction", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard("11" + noSolutionProblem[2:])
+		assert.NoError(t, err)
+
+		_, _, err = solver.Solve(board)
+		assert.ErrorIs(t, err, sudoku.ErrContradiction)
+	})
+
+	t.Run("board is left unchanged", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		before := board.Duplicate()
+
+		singles := &sudoku.LogicalSolver{Techniques: sudoku.DefaultTechniques()[:2]}
+		_, _, err = singles.Solve(board)
+		assert.ErrorIs(t, err, sudoku.ErrStuck)
+		assert.Equal(t, before, board)
+	})
+}
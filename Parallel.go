@@ -0,0 +1,188 @@
+package sudoku
+
+import (
+	"context"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// tasksPerWorker is the number of subproblems queued per worker, so that
+	// workers finishing early have more to pick from.
+	tasksPerWorker = 8
+	// maxSplitDepth bounds the number of search levels expanded up front.
+	maxSplitDepth = 6
+)
+
+// Parallel solves boards by splitting the top levels of the backtracking
+// search into independent subproblems, solved by a bounded pool of workers.
+// It returns the same solution as Backtracking.
+type Parallel struct {
+	// Workers is the number of goroutines searching concurrently. Zero or
+	// less means runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+func (Parallel) Name() string {
+	return "parallel"
+}
+
+func (e Parallel) Solve(ctx context.Context, b *Board) (*Board, error) {
+	return SolveParallel(ctx, b, e.Workers)
+}
+
+func (e Parallel) CountSolutions(ctx context.Context, b *Board, limit int) (int, error) {
+	b, err := propagated(b)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var count atomic.Int64
+	runParallel(ctx, b, e.Workers, func(taskCtx context.Context, _ int, board *Board) {
+		newSearcher(taskCtx, SolveOptions{}).search(board, 0, func(*Board) bool {
+			if n := count.Add(1); limit > 0 && n >= int64(limit) {
+				cancel()
+				return false
+			}
+			return true
+		})
+	})
+
+	n := int(count.Load())
+	if limit > 0 && n >= limit {
+		return limit, nil
+	}
+	return n, ctx.Err()
+}
+
+// SolveParallel is like SolveContext, but searches the branches of the top
+// levels of the search concurrently, with at most workers goroutines. As soon
+// as a branch finds a solution, the branches that the sequential search would
+// have visited after it are cancelled, so that the result is the same.
+func SolveParallel(ctx context.Context, b *Board, workers int) (*Board, error) {
+	b, err := propagated(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		best    = -1
+		solved  *Board
+		cancels = make(map[int]context.CancelFunc)
+	)
+
+	runParallel(ctx, b, workers, func(taskCtx context.Context, task int, board *Board) {
+		taskCtx, cancel := context.WithCancel(taskCtx)
+		defer cancel()
+
+		mu.Lock()
+		if best != -1 && best < task {
+			// An earlier branch already has a solution.
+			mu.Unlock()
+			return
+		}
+		cancels[task] = cancel
+		mu.Unlock()
+
+		var found *Board
+		newSearcher(taskCtx, SolveOptions{}).search(board, 0, func(s *Board) bool {
+			found = s
+			return false
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+		delete(cancels, task)
+		if found == nil || (best != -1 && best < task) {
+			return
+		}
+		best, solved = task, found
+		for other, cancel := range cancels {
+			if other > task {
+				cancel()
+			}
+		}
+	})
+
+	if solved != nil {
+		return solved, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrUnsolvable
+}
+
+// runParallel splits the board into subproblems and calls solve for each of
+// them from a pool of workers, passing the index of the subproblem in the
+// sequential search order. It returns once every call has returned.
+func runParallel(ctx context.Context, b *Board, workers int, solve func(ctx context.Context, task int, board *Board)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	boards := splitBoard(b, workers*tasksPerWorker)
+	tasks := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(boards)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				if ctx.Err() == nil {
+					solve(ctx, task, boards[task])
+				}
+			}
+		}()
+	}
+
+	for task := range boards {
+		tasks <- task
+	}
+	close(tasks)
+	wg.Wait()
+}
+
+// splitBoard expands the top levels of the search until there are at least n
+// boards to explore, returning them in the order the sequential search would
+// visit them. Branches rejected by constraint propagation are dropped.
+func splitBoard(b *Board, n int) []*Board {
+	frontier := []*Board{b}
+
+	for range maxSplitDepth {
+		if len(frontier) >= n {
+			break
+		}
+
+		expanded := false
+		next := make([]*Board, 0, 2*len(frontier))
+		for _, board := range frontier {
+			square := nextEmptySquare(board)
+			if square == -1 {
+				next = append(next, board)
+				continue
+			}
+
+			expanded = true
+			for mask := board.squares[square]; mask != 0; mask &= mask - 1 {
+				newBoard := board.Duplicate()
+				if newBoard.assign(square, bits.TrailingZeros16(mask)+1) {
+					next = append(next, newBoard)
+				}
+			}
+		}
+
+		frontier = next
+		if !expanded {
+			break
+		}
+	}
+	return frontier
+}
@@ -0,0 +1,92 @@
+package sudoku_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveParallel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("same result as the sequential solver", func(t *testing.T) {
+		for _, boardString := range hardProblems {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, err := sudoku.SolveParallel(ctx, board, 4)
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String(), boardString)
+		}
+	})
+
+	t.Run("first solution of a board with several", func(t *testing.T) {
+		for _, boardString := range []string{"", multipleSolutionsProblem} {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			for _, workers := range []int{0, 1, 3, 16} {
+				solved, err := sudoku.SolveParallel(ctx, board, workers)
+				assert.NoError(t, err)
+				assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+			}
+		}
+	})
+
+	t.Run("already solved board", func(t *testing.T) {
+		board, err := sudoku.NewBoard(easyProblems[0])
+		assert.NoError(t, err)
+
+		solved, err := sudoku.SolveParallel(ctx, board, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, board.String(), solved.String())
+	})
+
+	t.Run("no solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		solved, err := sudoku.SolveParallel(ctx, board, 4)
+		assert.ErrorIs(t, err, sudoku.ErrUnsolvable)
+		assert.Nil(t, solved)
+	})
+
+	t.Run("count solutions", func(t *testing.T) {
+		engine := sudoku.Parallel{Workers: 4}
+
+		board, err := sudoku.NewBoard(multipleSolutionsProblem)
+		assert.NoError(t, err)
+
+		count, err := engine.CountSolutions(ctx, board, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		board, err = sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		count, err = engine.CountSolutions(ctx, board, 50)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, count)
+	})
+}
+
+func BenchmarkSolveParallel(b *testing.B) {
+	boards := make([]*sudoku.Board, len(hardProblems))
+	for i, boardString := range hardProblems {
+		board, err := sudoku.NewBoard(boardString)
+		if err != nil {
+			b.Fatal(err)
+		}
+		boards[i] = board
+	}
+
+	for b.Loop() {
+		for _, board := range boards {
+			if _, err := sudoku.SolveParallel(context.Background(), board, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -1,39 +1,233 @@
 package sudoku
 
-func nextEmptySquare(b *Board) (int, int) {
-	minRow, minColumn, minCount := -1, -1, numDigits+1
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math/bits"
+	"time"
+)
+
+var (
+	ErrUnsolvable        = fmt.Errorf("board has no solution")
+	ErrMultipleSolutions = fmt.Errorf("board has multiple solutions")
+	ErrContradiction     = fmt.Errorf("board contradicts itself")
+	ErrSearchLimit       = fmt.Errorf("search limit reached")
+)
+
+// SolveOptions bounds the work done by SolveContext. Zero values mean no limit.
+type SolveOptions struct {
+	// MaxNodes is the maximum number of boards visited by the search.
+	MaxNodes int
+	// MaxGuesses is the maximum number of values tried on squares with
+	// several possible values.
+	MaxGuesses int
+}
+
+// SolveStats describes the work done by a search.
+type SolveStats struct {
+	// Nodes is the number of boards visited.
+	Nodes int
+	// Guesses is the number of values tried on squares with several
+	// possible values.
+	Guesses int
+	// DeadEnds is the number of guesses rejected by constraint propagation.
+	DeadEnds int
+	// Eliminations is the number of possible values removed by constraint
+	// propagation.
+	Eliminations int
+	// MaxDepth is the deepest level of guessing reached.
+	MaxDepth int
+	// Elapsed is the wall-clock duration of the search.
+	Elapsed time.Duration
+}
+
+func nextEmptySquare(b *Board) int {
+	minSquare, minCount := -1, numDigits+1
 
 	// Search for the empty square that has the least amount of possible values.
-	for i := range numRows {
-		for j := range numColumns {
-			possible, _ := b.CountPossible(i, j)
-			if possible > 1 && possible < minCount {
-				minRow, minColumn, minCount = i, j, possible
-			}
+	for i := range numSquares {
+		possible := b.countPossible(i)
+		if possible > 1 && possible < minCount {
+			minSquare, minCount = i, possible
 		}
 	}
-	return minRow, minColumn
+	return minSquare
 }
 
-func Solver(b *Board) *Board {
-	row, column := nextEmptySquare(b)
-	if row == -1 || column == -1 {
-		return b
+// propagated returns a copy of the board with constraints propagated, or
+// ErrContradiction if the board breaks the rules of sudoku.
+func propagated(b *Board) (*Board, error) {
+	newBoard := b.Duplicate()
+	if err := newBoard.Propagate(); err != nil {
+		return nil, err
+	}
+	return newBoard, nil
+}
+
+// searcher holds the state of a single backtracking search.
+type searcher struct {
+	ctx   context.Context
+	opts  SolveOptions
+	stats SolveStats
+
+	// err records why the search was interrupted, if it was.
+	err error
+}
+
+func newSearcher(ctx context.Context, opts SolveOptions) *searcher {
+	return &searcher{ctx: ctx, opts: opts}
+}
+
+// search walks the solutions of the board depth-first, calling yield for each
+// one. It returns false if yield asked to stop the search or if the search was
+// interrupted.
+func (s *searcher) search(b *Board, depth int, yield func(*Board) bool) bool {
+	// Deeper boards went through constraint propagation, but the initial
+	// board may be raw, or left contradictory by a failed Propagate.
+	if depth == 0 {
+		var err error
+		if b, err = propagated(b); err != nil {
+			s.err = err
+			return false
+		}
+	}
+	if s.opts.MaxNodes > 0 && s.stats.Nodes >= s.opts.MaxNodes {
+		s.err = ErrSearchLimit
+		return false
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
 	}
+	s.stats.Nodes++
+	s.stats.MaxDepth = max(s.stats.MaxDepth, depth)
+
+	square := nextEmptySquare(b)
+	if square == -1 {
+		return yield(b)
+	}
+	candidates := b.candidateCount()
 	// Try each possible value for this square.
-	for _, c := range b[row][column] {
-		value := int(c - '0')
+	for mask := b.squares[square]; mask != 0; mask &= mask - 1 {
+		value := bits.TrailingZeros16(mask) + 1
+
+		if s.opts.MaxGuesses > 0 && s.stats.Guesses >= s.opts.MaxGuesses {
+			s.err = ErrSearchLimit
+			return false
+		}
+		s.stats.Guesses++
 
 		// Apply modifications to a duplicate board.
 		newBoard := b.Duplicate()
-		if err := newBoard.assign(row, column, value); err != nil {
+		ok := newBoard.assign(square, value)
+		s.stats.Eliminations += candidates - newBoard.candidateCount()
+		if !ok {
+			s.stats.DeadEnds++
 			continue
 		}
 
 		// Try solving the board with this value.
-		if solved := Solver(newBoard); solved != nil {
-			return solved
+		if !s.search(newBoard, depth+1, yield) {
+			return false
 		}
 	}
+	return true
+}
+
+// Solver returns the first solution found for the board, or nil if it has none.
+func Solver(b *Board) *Board {
+	for solved := range Solutions(b) {
+		return solved
+	}
 	return nil
 }
+
+// Solve returns the solution of a board that must have exactly one. It returns
+// ErrContradiction if the board breaks the rules of sudoku, for instance a raw
+// board from NewRawBoard or one on which Propagate found a contradiction,
+// ErrUnsolvable if it has no solution and ErrMultipleSolutions if it has more
+// than one.
+func Solve(b *Board) (*Board, error) {
+	var solved *Board
+
+	s := newSearcher(context.Background(), SolveOptions{})
+	count := 0
+	s.search(b, 0, func(b *Board) bool {
+		solved = b
+		count++
+		return count < 2
+	})
+
+	switch {
+	case s.err != nil:
+		return nil, s.err
+	case count == 0:
+		return nil, ErrUnsolvable
+	case count > 1:
+		return nil, ErrMultipleSolutions
+	}
+	return solved, nil
+}
+
+// SolveContext is like Solver, but stops the search when ctx is done or when
+// one of the limits in opts is reached. It returns ErrContradiction if the
+// board breaks the rules of sudoku, ErrUnsolvable if it has no solution,
+// ErrSearchLimit if a limit was reached, or the context's error.
+func SolveContext(ctx context.Context, b *Board, opts SolveOptions) (*Board, error) {
+	solved, _, err := SolveWithStats(ctx, b, opts)
+	return solved, err
+}
+
+// SolveWithStats is like SolveContext, but also reports the work done by the
+// search, whether or not it succeeded.
+func SolveWithStats(ctx context.Context, b *Board, opts SolveOptions) (*Board, SolveStats, error) {
+	var solved *Board
+
+	start := time.Now()
+	s := newSearcher(ctx, opts)
+	s.search(b, 0, func(b *Board) bool {
+		solved = b
+		return false
+	})
+	s.stats.Elapsed = time.Since(start)
+
+	if solved != nil {
+		return solved, s.stats, nil
+	}
+	if s.err != nil {
+		return nil, s.stats, s.err
+	}
+	return nil, s.stats, ErrUnsolvable
+}
+
+// Solutions returns an iterator over every solution of the board, in the
+// order the search finds them. Solutions are computed lazily, so breaking out
+// of the loop stops the search.
+func Solutions(b *Board) iter.Seq[*Board] {
+	return func(yield func(*Board) bool) {
+		newSearcher(context.Background(), SolveOptions{}).search(b, 0, yield)
+	}
+}
+
+// CountSolutions returns the number of solutions of the board, stopping once
+// limit solutions have been found. A limit of zero or less counts them all.
+func CountSolutions(b *Board, limit int) int {
+	count, _ := countSolutions(context.Background(), b, limit, SolveOptions{})
+	return count
+}
+
+func countSolutions(ctx context.Context, b *Board, limit int, opts SolveOptions) (int, error) {
+	count := 0
+	s := newSearcher(ctx, opts)
+	s.search(b, 0, func(*Board) bool {
+		count++
+		return count != limit
+	})
+	return count, s.err
+}
+
+// IsUnique reports whether the board has exactly one solution.
+func IsUnique(b *Board) bool {
+	return CountSolutions(b, 2) == 1
+}
@@ -1,7 +1,9 @@
 package sudoku_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/kroosec/sudoku-go"
 	"github.com/stretchr/testify/assert"
@@ -197,3 +199,280 @@ func assertProblem(t *testing.T, boardString string) {
 		assert.NoError(t, err)
 	})
 }
+
+// A puzzle with two solutions: a rectangle of four squares that can be swapped.
+const multipleSolutionsProblem = "4.3921.579.7345.21251876493548132976729564138136798245372689514814253769695417382"
+
+// A puzzle without solutions: no square of the top-left box can hold a 1.
+const noSolutionProblem = ".....1...........1..2......1...........................1........................."
+
+// contradictoryBoard returns a raw board breaking the rules, left
+// contradictory by Propagate.
+func contradictoryBoard(t *testing.T) *sudoku.Board {
+	t.Helper()
+
+	board, err := sudoku.NewRawBoard(easyProblems[1])
+	assert.NoError(t, err)
+	assert.NoError(t, board.SetValue(0, 1, 1))
+	assert.ErrorIs(t, board.Propagate(), sudoku.ErrContradiction)
+	return board
+}
+
+func TestSolve(t *testing.T) {
+	t.Run("unique solution", func(t *testing.T) {
+		for _, boardString := range hardProblems[:5] {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, err := sudoku.Solve(board)
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		type testCase struct {
+			name  string
+			board func(t *testing.T) *sudoku.Board
+			err   error
+		}
+
+		fromString := func(boardString string) func(t *testing.T) *sudoku.Board {
+			return func(t *testing.T) *sudoku.Board {
+				board, err := sudoku.NewBoard(boardString)
+				assert.NoError(t, err)
+				return board
+			}
+		}
+
+		cases := []testCase{
+			{"No solution", fromString(noSolutionProblem), sudoku.ErrUnsolvable},
+			{"Multiple solutions", fromString(multipleSolutionsProblem), sudoku.ErrMultipleSolutions},
+			{"Empty board", fromString(""), sudoku.ErrMultipleSolutions},
+			{"Contradiction", contradictoryBoard, sudoku.ErrContradiction},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				solved, err := sudoku.Solve(c.board(t))
+				assert.ErrorIs(t, err, c.err)
+				assert.Nil(t, solved)
+			})
+		}
+	})
+
+	t.Run("contradictory board has no solution", func(t *testing.T) {
+		board := contradictoryBoard(t)
+
+		assert.Nil(t, sudoku.Solver(board))
+		assert.Equal(t, 0, sudoku.CountSolutions(board, 0))
+
+		_, err := sudoku.SolveContext(context.Background(), board, sudoku.SolveOptions{})
+		assert.ErrorIs(t, err, sudoku.ErrContradiction)
+	})
+}
+
+func TestCountSolutions(t *testing.T) {
+	t.Run("well-formed problems have a unique solution", func(t *testing.T) {
+		for _, boardString := range append(easyProblems[:10:10], hardProblems[:5]...) {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			assert.Equal(t, 1, sudoku.CountSolutions(board, 0))
+			assert.True(t, sudoku.IsUnique(board))
+		}
+	})
+
+	t.Run("count stops at the limit", func(t *testing.T) {
+		board, err := sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		assert.Equal(t, 5, sudoku.CountSolutions(board, 5))
+		assert.False(t, sudoku.IsUnique(board))
+	})
+
+	t.Run("multiple solutions", func(t *testing.T) {
+		board, err := sudoku.NewBoard(multipleSolutionsProblem)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, sudoku.CountSolutions(board, 0))
+		assert.Equal(t, 1, sudoku.CountSolutions(board, 1))
+		assert.False(t, sudoku.IsUnique(board))
+	})
+
+	t.Run("no solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 0, sudoku.CountSolutions(board, 0))
+		assert.False(t, sudoku.IsUnique(board))
+	})
+}
+
+func TestSolutions(t *testing.T) {
+	t.Run("enumerate every solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(multipleSolutionsProblem)
+		assert.NoError(t, err)
+
+		seen := make(map[string]struct{})
+		for solved := range sudoku.Solutions(board) {
+			_, err := sudoku.NewBoard(solved.String())
+			assert.NoError(t, err)
+			assert.NotContains(t, solved.String(), ".")
+			seen[solved.String()] = struct{}{}
+		}
+		assert.Len(t, seen, 2)
+	})
+
+	t.Run("first solution matches Solver", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		for solved := range sudoku.Solutions(board) {
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+			break
+		}
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		board, err := sudoku.NewBoard("")
+		assert.NoError(t, err)
+
+		count := 0
+		for range sudoku.Solutions(board) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("no solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		for range sudoku.Solutions(board) {
+			assert.Fail(t, "unexpected solution")
+		}
+	})
+}
+
+func TestSolveContext(t *testing.T) {
+	t.Run("solve hard problems", func(t *testing.T) {
+		for _, boardString := range hardProblems[:5] {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			solved, err := sudoku.SolveContext(context.Background(), board, sudoku.SolveOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+		}
+	})
+
+	t.Run("no solution", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		solved, err := sudoku.SolveContext(context.Background(), board, sudoku.SolveOptions{})
+		assert.ErrorIs(t, err, sudoku.ErrUnsolvable)
+		assert.Nil(t, solved)
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		_, err = sudoku.SolveContext(context.Background(), board, sudoku.SolveOptions{MaxNodes: 10})
+		assert.ErrorIs(t, err, sudoku.ErrSearchLimit)
+
+		_, err = sudoku.SolveContext(context.Background(), board, sudoku.SolveOptions{MaxGuesses: 10})
+		assert.ErrorIs(t, err, sudoku.ErrSearchLimit)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = sudoku.SolveContext(ctx, board, sudoku.SolveOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		_, err = sudoku.SolveContext(ctx, board, sudoku.SolveOptions{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestSolveWithStats(t *testing.T) {
+	t.Run("solved board needs no search", func(t *testing.T) {
+		board, err := sudoku.NewBoard(easyProblems[0])
+		assert.NoError(t, err)
+
+		solved, stats, err := sudoku.SolveWithStats(context.Background(), board, sudoku.SolveOptions{})
+		assert.NoError(t, err)
+		assert.NotNil(t, solved)
+		assert.Equal(t, 1, stats.Nodes)
+		assert.Zero(t, stats.Guesses)
+		assert.Zero(t, stats.DeadEnds)
+		assert.Zero(t, stats.Eliminations)
+		assert.Zero(t, stats.MaxDepth)
+	})
+
+	t.Run("hard problem", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		solved, stats, err := sudoku.SolveWithStats(context.Background(), board, sudoku.SolveOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, sudoku.Solver(board).String(), solved.String())
+		assert.Greater(t, stats.Nodes, 1)
+		assert.GreaterOrEqual(t, stats.Guesses, stats.Nodes-1)
+		assert.Greater(t, stats.DeadEnds, 0)
+		assert.Greater(t, stats.Eliminations, 0)
+		assert.Greater(t, stats.MaxDepth, 0)
+		assert.Less(t, stats.MaxDepth, stats.Nodes)
+		assert.Positive(t, stats.Elapsed)
+	})
+
+	t.Run("stats are reported on failure", func(t *testing.T) {
+		board, err := sudoku.NewBoard(noSolutionProblem)
+		assert.NoError(t, err)
+
+		_, stats, err := sudoku.SolveWithStats(context.Background(), board, sudoku.SolveOptions{MaxNodes: 10})
+		assert.ErrorIs(t, err, sudoku.ErrSearchLimit)
+		assert.Equal(t, 10, stats.Nodes)
+	})
+}
+
+func BenchmarkSolver(b *testing.B) {
+	b.Run("easy", func(b *testing.B) {
+		benchmarkSolver(b, easyProblems)
+	})
+
+	b.Run("hard", func(b *testing.B) {
+		benchmarkSolver(b, hardProblems)
+	})
+}
+
+func benchmarkSolver(b *testing.B, problems []string) {
+	boards := make([]*sudoku.Board, len(problems))
+	for i, boardString := range problems {
+		board, err := sudoku.NewBoard(boardString)
+		if err != nil {
+			b.Fatal(err)
+		}
+		boards[i] = board
+	}
+
+	for b.Loop() {
+		for _, board := range boards {
+			if sudoku.Solver(board) == nil {
+				b.Fatal("could not solve board")
+			}
+		}
+	}
+}
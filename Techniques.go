@@ -0,0 +1,229 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// unitOrder is the order units are searched in: boxes first, as humans
+// usually do, then rows and columns.
+var unitOrder = func() []int {
+	order := make([]int, 0, numUnits)
+	for u := 2 * numDigits; u < numUnits; u++ {
+		order = append(order, u)
+	}
+	for u := range 2 * numDigits {
+		order = append(order, u)
+	}
+	return order
+}()
+
+var subsetNames = map[int]string{2: "Pair", 3: "Triple", 4: "Quad"}
+
+// nakedSingle places the value of a square with a single candidate left.
+var nakedSingle = technique{name: "Naked Single", find: func(g *grid) *Step {
+	for s, mask := range g.candidates {
+		if bits.OnesCount16(mask) == 1 {
+			step := &Step{Placements: []Candidate{{Square: squareAt(s), Value: maskValues(mask)[0]}}}
+			return newStep(step, []int{s}, mask).because("Only %v is left in %v", valueMask(mask), squareAt(s))
+		}
+	}
+	return nil
+}}
+
+// hiddenSingle places a value that has a single possible square in a unit.
+var hiddenSingle = technique{name: "Hidden Single", find: func(g *grid) *Step {
+	for _, u := range unitOrder {
+		for value := 1; value <= numDigits; value++ {
+			positions := g.positions(u, value)
+			if bits.OnesCount16(positions) != 1 {
+				continue
+			}
+
+			s := unitSquares[u][bits.TrailingZeros16(positions)]
+			step := &Step{
+				Units:      unitsOf(u),
+				Placements: []Candidate{{Square: squareAt(s), Value: value}},
+			}
+			return newStep(step, []int{s}, valueBit(value)).because("In %v, %d can only go in %v", unitAt(u), value, squareAt(s))
+		}
+	}
+	return nil
+}}
+
+// pointing rules out a value from a row or column outside a box, when the
+// value's candidates in the box all lie in that row or column.
+var pointing = technique{name: "Pointing", find: func(g *grid) *Step {
+	for box := 2 * numDigits; box < numUnits; box++ {
+		for value := 1; value <= numDigits; value++ {
+			squares := unitSquaresWith(g, box, value)
+			if len(squares) < 2 {
+				continue
+			}
+
+			for kind := range 2 {
+				line := squareUnits[squares[0]][kind]
+				if !allInUnit(squares, kind, line) {
+					continue
+				}
+
+				step := &Step{
+					Technique: fmt.Sprintf("Pointing %s", subsetNames[len(squares)]),
+					Units:     unitsOf(box, line),
+				}
+				g.eliminate(step, outside(unitSquares[line][:], box), valueBit(value))
+				if step := newStep(step, squares, valueBit(value)); step != nil {
+					return step.because("In %v, %d can only go in %v, which are also in %v", unitAt(box), value, squareList(squares), unitAt(line))
+				}
+			}
+		}
+	}
+	return nil
+}}
+
+// boxLineReduction rules out a value from a box outside a row or column, when
+// the value's candidates in the row or column all lie in that box.
+var boxLineReduction = technique{name: "Box/Line Reduction", find: func(g *grid) *Step {
+	for line := range 2 * numDigits {
+		for value := 1; value <= numDigits; value++ {
+			squares := unitSquaresWith(g, line, value)
+			if len(squares) < 2 {
+				continue
+			}
+
+			box := squareUnits[squares[0]][2]
+			if !allInUnit(squares, 2, box) {
+				continue
+			}
+
+			step := &Step{Units: unitsOf(line, box)}
+			g.eliminate(step, outside(unitSquares[box][:], line), valueBit(value))
+			if step := newStep(step, squares, valueBit(value)); step != nil {
+				return step.because("In %v, %d can only go in %v, which are also in %v", unitAt(line), value, squareList(squares), unitAt(box))
+			}
+		}
+	}
+	return nil
+}}
+
+// nakedSubset rules out the candidates of n squares of a unit from the rest of
+// the unit, when the n squares have only n candidates between them.
+func nakedSubset(n int) technique {
+	name := "Naked " + subsetNames[n]
+	return technique{name: name, find: func(g *grid) *Step {
+		for _, u := range unitOrder {
+			var squares []int
+			for _, s := range unitSquares[u] {
+				if count := bits.OnesCount16(g.candidates[s]); count >= 2 && count <= n {
+					squares = append(squares, s)
+				}
+			}
+
+			var found *Step
+			combinations(squares, n, func(subset []int) bool {
+				var values uint16
+				for _, s := range subset {
+					values |= g.candidates[s]
+				}
+				if bits.OnesCount16(values) != n {
+					return true
+				}
+
+				step := &Step{Units: unitsOf(u)}
+				g.eliminate(step, without(unitSquares[u][:], subset), values)
+				found = newStep(step, subset, values).because("In %v, %v only have %v as candidates between them", unitAt(u), squareList(subset), valueMask(values))
+				return found == nil
+			})
+			if found != nil {
+				return found
+			}
+		}
+		return nil
+	}}
+}
+
+// hiddenSubset rules out the other candidates of n squares of a unit, when n
+// values of the unit can only go in those squares.
+func hiddenSubset(n int) technique {
+	name := "Hidden " + subsetNames[n]
+	return technique{name: name, find: func(g *grid) *Step {
+		for _, u := range unitOrder {
+			var values []int
+			for value := 1; value <= numDigits; value++ {
+				if count := bits.OnesCount16(g.positions(u, value)); count >= 2 && count <= n {
+					values = append(values, value)
+				}
+			}
+
+			var found *Step
+			combinations(values, n, func(subset []int) bool {
+				var positions, mask uint16
+				for _, value := range subset {
+					positions |= g.positions(u, value)
+					mask |= valueBit(value)
+				}
+				if bits.OnesCount16(positions) != n {
+					return true
+				}
+
+				squares := make([]int, 0, n)
+				for ; positions != 0; positions &= positions - 1 {
+					squares = append(squares, unitSquares[u][bits.TrailingZeros16(positions)])
+				}
+
+				step := &Step{Units: unitsOf(u)}
+				g.eliminate(step, squares, allValues&^mask)
+				found = newStep(step, squares, mask).because("In %v, %v can only go in %v", unitAt(u), valueMask(mask), squareList(squares))
+				return found == nil
+			})
+			if found != nil {
+				return found
+			}
+		}
+		return nil
+	}}
+}
+
+// unitSquaresWith returns the squares of a unit having a value as candidate.
+func unitSquaresWith(g *grid, unit, value int) []int {
+	var squares []int
+	for _, s := range unitSquares[unit] {
+		if g.candidates[s]&valueBit(value) != 0 {
+			squares = append(squares, s)
+		}
+	}
+	return squares
+}
+
+// allInUnit reports whether the squares all lie in a unit of the given kind.
+func allInUnit(squares []int, kind, unit int) bool {
+	for _, s := range squares {
+		if squareUnits[s][kind] != unit {
+			return false
+		}
+	}
+	return true
+}
+
+// outside returns the squares that do not lie in a unit.
+func outside(squares []int, unit int) []int {
+	var result []int
+	for _, s := range squares {
+		if squareUnits[s][unit/numDigits] != unit {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// without returns the squares that are not excluded.
+func without(squares, excluded []int) []int {
+	var result []int
+	for _, s := range squares {
+		if !slices.Contains(excluded, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
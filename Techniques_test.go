@@ -0,0 +1,100 @@
+package sudoku_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// candidateBoard returns an empty raw board where the given squares only keep
+// the given candidates.
+func candidateBoard(t *testing.T, candidates map[sudoku.Square][]int) *sudoku.Board {
+	t.Helper()
+
+	board, err := sudoku.NewRawBoard("")
+	assert.NoError(t, err)
+	for square, values := range candidates {
+		for value := 1; value <= 9; value++ {
+			if !slices.Contains(values, value) {
+				assert.NoError(t, board.RemoveCandidate(square.Row, square.Column, value))
+			}
+		}
+	}
+	return board
+}
+
+// findStep returns the step found on the board by the default technique with
+// the given name.
+func findStep(t *testing.T, name string, board *sudoku.Board) *sudoku.Step {
+	t.Helper()
+
+	for _, technique := range sudoku.DefaultTechniques() {
+		if technique.Name() == name {
+			return technique.Find(board)
+		}
+	}
+	t.Fatalf("no technique named %s", name)
+	return nil
+}
+
+// rowCandidates returns the candidates of the squares of a row between two
+// columns, for each of the values.
+func rowCandidates(row, from, to int, values ...int) []sudoku.Candidate {
+	var candidates []sudoku.Candidate
+	for column := from; column < to; column++ {
+		for _, value := range values {
+			candidates = append(candidates, sudoku.Candidate{Square: sudoku.Square{Row: row, Column: column}, Value: value})
+		}
+	}
+	return candidates
+}
+
+func TestTechniques(t *testing.T) {
+	r1 := func(column int) sudoku.Square { return sudoku.Square{Row: 0, Column: column} }
+
+	t.Run("naked quad", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			r1(0): {1, 2}, r1(1): {2, 3}, r1(2): {3, 4}, r1(3): {1, 4},
+		})
+
+		step := findStep(t, "Naked Quad", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Naked Quad", step.Technique)
+		assert.Equal(t, "row 1", step.Units[0].String())
+		assert.Equal(t, []sudoku.Square{r1(0), r1(1), r1(2), r1(3)}, step.Squares)
+		assert.Equal(t, []int{1, 2, 3, 4}, step.Values)
+		assert.Empty(t, step.Placements)
+		assert.Equal(t, rowCandidates(0, 4, 9, 1, 2, 3, 4), step.Eliminations)
+	})
+
+	t.Run("hidden quad", func(t *testing.T) {
+		candidates := map[sudoku.Square][]int{}
+		for column := 4; column < 9; column++ {
+			candidates[r1(column)] = []int{5, 6, 7, 8, 9}
+		}
+		board := candidateBoard(t, candidates)
+
+		step := findStep(t, "Hidden Quad", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Hidden Quad", step.Technique)
+		assert.Equal(t, []sudoku.Square{r1(0), r1(1), r1(2), r1(3)}, step.Squares)
+		assert.Equal(t, []int{1, 2, 3, 4}, step.Values)
+		assert.Equal(t, rowCandidates(0, 0, 4, 5, 6, 7, 8, 9), step.Eliminations)
+
+		// The solver takes the same step.
+		_, steps, err := sudoku.NewLogicalSolver().Solve(board)
+		assert.ErrorIs(t, err, sudoku.ErrStuck)
+		assert.Equal(t, []sudoku.Step{*step}, steps)
+	})
+
+	t.Run("no step", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard("")
+		assert.NoError(t, err)
+
+		for _, technique := range sudoku.DefaultTechniques() {
+			assert.Nil(t, technique.Find(board), technique.Name())
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Trace is the transcript of a logical solve: the steps taken from the
+// puzzle, and the board they lead to.
+type Trace struct {
+	// Puzzle is the board string of the squares the solve starts from: the
+	// givens and the values set with SetValue. Values deduced by constraint
+	// propagation are left out, as the steps place them.
+	Puzzle string `json:"puzzle"`
+	Steps  []Step `json:"steps"`
+	// Result is the board string of the squares solved after the steps, in
+	// the same way, and Solved is set if every square is.
+	Result string `json:"result"`
+	Solved bool   `json:"solved"`
+}
+
+// Trace solves a board like Solve, and returns the transcript of the solve.
+// It returns the trace and ErrStuck if the solver gets stuck.
+func (s *LogicalSolver) Trace(b *Board) (*Trace, error) {
+	board, steps, err := s.Solve(b)
+	if board == nil {
+		return nil, err
+	}
+
+	trace := &Trace{
+		Puzzle: b.solvedString(),
+		Steps:  steps,
+		Result: board.solvedString(),
+		Solved: err == nil,
+	}
+	return trace, err
+}
+
+// String renders the trace as text, a numbered step per line.
+func (t *Trace) String() string {
+	var str strings.Builder
+
+	fmt.Fprintf(&str, "Puzzle: %s\n", t.Puzzle)
+	for i, step := range t.Steps {
+		fmt.Fprintf(&str, "%d. %v\n", i+1, step)
+	}
+	if t.Solved {
+		fmt.Fprintf(&str, "Solved: %s\n", t.Result)
+	} else {
+		fmt.Fprintf(&str, "Stuck: %s\n", t.Result)
+	}
+	return str.String()
+}
+
+// String returns the technique and the explanation of the step.
+func (s Step) String() string {
+	return fmt.Sprintf("%s: %s", s.Technique, s.Explanation)
+}
+
+// because sets the reason of a step, the clause saying why it holds, which
+// explain completes with what the step does. It returns the step, which may
+// be nil.
+func (s *Step) because(format string, args ...any) *Step {
+	if s != nil {
+		s.Explanation = fmt.Sprintf(format, args...)
+	}
+	return s
+}
+
+// explain returns a sentence explaining a step to a human, from its reason.
+// Steps without a reason, from techniques that do not give one, get a
+// generic one.
+func explain(step *Step) string {
+	reason := step.Explanation
+	if reason == "" {
+		reason = fmt.Sprintf("%s applies", step.Technique)
+		if len(step.Squares) > 0 {
+			reason += " to " + joinSquares(step.Squares)
+		}
+	}
+
+	var outcomes []string
+	for _, c := range step.Placements {
+		outcomes = append(outcomes, fmt.Sprintf("%v is %d", c.Square, c.Value))
+	}
+
+	// Group the eliminations by value, in order of first appearance.
+	var order []int
+	ruledOut := map[int][]Square{}
+	for _, c := range step.Eliminations {
+		if _, ok := ruledOut[c.Value]; !ok {
+			order = append(order, c.Value)
+		}
+		ruledOut[c.Value] = append(ruledOut[c.Value], c.Square)
+	}
+	for _, value := range order {
+		outcomes = append(outcomes, fmt.Sprintf("%d is ruled out from %s", value, joinSquares(ruledOut[value])))
+	}
+
+	if len(outcomes) == 0 {
+		return reason + "."
+	}
+	return fmt.Sprintf("%s, so %s.", reason, strings.Join(outcomes, "; "))
+}
+
+// squareList and valueMask format squares and values in reasons.
+type squareList []int
+
+func (l squareList) String() string {
+	squares := make([]Square, len(l))
+	for i, s := range l {
+		squares[i] = squareAt(s)
+	}
+	return joinSquares(squares)
+}
+
+type valueMask uint16
+
+func (m valueMask) String() string {
+	return joinValues(maskValues(uint16(m)))
+}
+
+func joinSquares(squares []Square) string {
+	return join(squares, ", ")
+}
+
+func joinValues(values []int) string {
+	return join(values, ", ")
+}
+
+func joinUnits(units []Unit) string {
+	return join(units, ", ")
+}
+
+// joinChain returns a chain in the usual notation, where = is a strong link
+// and - a weak one.
+func joinChain(chain []Candidate) string {
+	var str strings.Builder
+	for i, c := range chain {
+		if i > 0 {
+			str.WriteString([]string{" - ", " = "}[i%2])
+		}
+		str.WriteString(c.String())
+	}
+	return str.String()
+}
+
+func join[T any](items []T, sep string) string {
+	strs := make([]string, len(items))
+	for i, item := range items {
+		strs[i] = fmt.Sprint(item)
+	}
+	return strings.Join(strs, sep)
+}
@@ -0,0 +1,170 @@
+package sudoku_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	solver := sudoku.NewLogicalSolver()
+
+	t.Run("text", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		trace, err := solver.Trace(board)
+		assert.NoError(t, err)
+		assert.True(t, trace.Solved)
+		assert.Equal(t, board.PuzzleString(), trace.Puzzle)
+		assert.Equal(t, sudoku.Solver(board).String(), trace.Result)
+
+		lines := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+		assert.Len(t, lines, len(trace.Steps)+2)
+		assert.Equal(t, "Puzzle: "+trace.Puzzle, lines[0])
+		assert.Equal(t, "1. Hidden Single: In box 4, 4 can only go in r6c2, so r6c2 is 4.", lines[1])
+		assert.Equal(t, "Solved: "+trace.Result, lines[len(lines)-1])
+
+		for _, step := range trace.Steps {
+			assert.NotEmpty(t, step.Explanation)
+		}
+	})
+
+	t.Run("puzzle leaves out deduced values", func(t *testing.T) {
+		// NewBoard deduces every value of this puzzle.
+		board, err := sudoku.NewBoard(easyProblems[0])
+		assert.NoError(t, err)
+		assert.True(t, board.IsSolved())
+
+		trace, err := solver.Trace(board)
+		assert.NoError(t, err)
+		assert.Equal(t, board.PuzzleString(), trace.Puzzle)
+		assert.NotEqual(t, board.String(), trace.Puzzle)
+		assert.Equal(t, board.String(), trace.Result)
+		assert.Equal(t, strings.Count(trace.Puzzle, "."), len(trace.Steps))
+	})
+
+	t.Run("puzzle includes values set", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+		assert.NoError(t, board.SetValue(5, 1, 4))
+
+		trace, err := solver.Trace(board)
+		assert.NoError(t, err)
+		puzzle := []byte(board.PuzzleString())
+		puzzle[5*9+1] = '4'
+		assert.Equal(t, string(puzzle), trace.Puzzle)
+	})
+
+	t.Run("explanations", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			{Row: 0, Column: 0}: {1, 2}, {Row: 0, Column: 4}: {1, 3}, {Row: 4, Column: 0}: {2, 3},
+		})
+
+		step := findStep(t, "XY-Wing", board)
+		assert.Equal(t, "Whichever of 1, 2 the pivot r1c1 holds, r1c5 or r5c1 holds 3, so 3 is ruled out from r5c5.", step.Explanation)
+
+		board = candidateBoard(t, map[sudoku.Square][]int{
+			{Row: 0, Column: 0}: {1, 2}, {Row: 0, Column: 1}: {1, 2}, {Row: 0, Column: 2}: {1, 2, 3},
+		})
+		step = findStep(t, "Naked Pair", board)
+		assert.Equal(t, "In box 1, r1c1, r1c2 only have 1, 2 as candidates between them, so "+
+			"1 is ruled out from r1c3, r2c1, r2c2, r2c3, r3c1, r3c2, r3c3; "+
+			"2 is ruled out from r1c3, r2c1, r2c2, r2c3, r3c1, r3c2, r3c3.", step.Explanation)
+
+		board = candidateBoard(t, map[sudoku.Square][]int{
+			{Row: 0, Column: 0}: {1, 2}, {Row: 0, Column: 1}: {1, 2},
+			{Row: 3, Column: 0}: {1, 2}, {Row: 3, Column: 1}: {1, 2, 3, 4},
+		})
+		step = findStep(t, "Unique Rectangle", board)
+		assert.Equal(t, "The rectangle r1c1, r1c2, r4c1, r4c2 must not be left with only 1, 2, which could be swapped: "+
+			"r1c1, r1c2, r4c1 already are, so 1 is ruled out from r4c2; 2 is ruled out from r4c2.", step.Explanation)
+	})
+
+	t.Run("custom technique", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		// The step has no units nor explanation, whatever its name.
+		solver := &sudoku.LogicalSolver{Techniques: []sudoku.Technique{stepTechnique{&sudoku.Step{
+			Technique:  "Naked Guess",
+			Squares:    []sudoku.Square{{Row: 5, Column: 1}},
+			Placements: []sudoku.Candidate{{Square: sudoku.Square{Row: 5, Column: 1}, Value: 4}},
+		}}}}
+		step, err := solver.NextHint(board)
+		assert.NoError(t, err)
+		assert.Equal(t, "Naked Guess applies to r6c2, so r6c2 is 4.", step.Explanation)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		trace, err := solver.Trace(board)
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(trace)
+		assert.NoError(t, err)
+
+		var decoded sudoku.Trace
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, trace.Puzzle, decoded.Puzzle)
+		assert.Equal(t, trace.Result, decoded.Result)
+		assert.True(t, decoded.Solved)
+		assert.Len(t, decoded.Steps, len(trace.Steps))
+		assert.Equal(t, trace.Steps[0].Placements, decoded.Steps[0].Placements)
+		assert.Equal(t, trace.Steps[0].Explanation, decoded.Steps[0].Explanation)
+		for i, step := range trace.Steps {
+			assert.Equal(t, step, decoded.Steps[i])
+		}
+
+		var raw map[string]any
+		assert.NoError(t, json.Unmarshal(data, &raw))
+		step := raw["steps"].([]any)[0].(map[string]any)
+		assert.Equal(t, "Hidden Single", step["technique"])
+		assert.Equal(t, []any{map[string]any{"kind": "box", "index": 3.0}}, step["units"])
+		assert.Equal(t, []any{map[string]any{"row": 5.0, "column": 1.0, "value": 4.0}}, step["placements"])
+		assert.NotContains(t, step, "eliminations")
+
+		var unit sudoku.Unit
+		assert.Error(t, json.Unmarshal([]byte(`{"kind": "row", "index": 9}`), &unit))
+		assert.Error(t, json.Unmarshal([]byte(`{"kind": "ring", "index": 0}`), &unit))
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		singles := &sudoku.LogicalSolver{Techniques: sudoku.DefaultTechniques()[:2]}
+		trace, err := singles.Trace(board)
+		assert.ErrorIs(t, err, sudoku.ErrStuck)
+		assert.False(t, trace.Solved)
+		assert.True(t, strings.HasSuffix(trace.String(), "Stuck: "+trace.Result+"\n"))
+	})
+
+	t.Run("contradiction", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard("11" + noSolutionProblem[2:])
+		assert.NoError(t, err)
+
+		trace, err := solver.Trace(board)
+		assert.ErrorIs(t, err, sudoku.ErrContradiction)
+		assert.Nil(t, trace)
+	})
+}
+
+// stepTechnique is a technique always giving the same step.
+type stepTechnique struct {
+	step *sudoku.Step
+}
+
+func (t stepTechnique) Name() string {
+	return t.step.Technique
+}
+
+func (t stepTechnique) Find(b *sudoku.Board) *sudoku.Step {
+	step := *t.step
+	return &step
+}
@@ -0,0 +1,311 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// AssumesUniqueness reports whether a technique relies on the board having a
+// unique solution, and may rule out candidates of a solution otherwise.
+// Techniques tell so by implementing an AssumesUniqueness method.
+func AssumesUniqueness(t Technique) bool {
+	u, ok := t.(interface{ AssumesUniqueness() bool })
+	return ok && u.AssumesUniqueness()
+}
+
+// A deadly pattern is a set of candidates that could be swapped in any
+// solution holding them, which would then not be unique. The techniques below
+// rule out the candidates completing a deadly pattern.
+
+// deadlyReason starts the reason of the rectangle techniques.
+const deadlyReason = "The rectangle %v must not be left with only %v, which could be swapped: "
+
+// uniqueRectangle works on four squares in two rows, two columns and two
+// boxes, that have two values a and b as candidates. Were the squares left
+// with a and b only, the values could be swapped.
+var uniqueRectangle = technique{name: "Unique Rectangle", uniqueness: true, find: func(g *grid) *Step {
+	var found *Step
+	g.rectangles(func(corners [4]int, ab uint16) bool {
+		found = g.uniqueRectangleStep(corners, ab)
+		return found == nil
+	})
+	return found
+}}
+
+func (g *grid) uniqueRectangleStep(corners [4]int, ab uint16) *Step {
+	var floor, roof []int
+	extras := uint16(0)
+	for _, s := range corners {
+		if g.candidates[s] == ab {
+			floor = append(floor, s)
+		} else {
+			roof = append(roof, s)
+			extras |= g.candidates[s] &^ ab
+		}
+	}
+
+	urStep := func(kind int, units ...int) *Step {
+		return &Step{Technique: fmt.Sprintf("Unique Rectangle Type %d", kind), Units: unitsOf(units...)}
+	}
+	// The reasons of the types complete the deadly pattern's.
+	because := func(step *Step, format string, args ...any) *Step {
+		return step.because(deadlyReason+format, append([]any{squareList(corners[:]), valueMask(ab)}, args...)...)
+	}
+	newURStep := func(step *Step, format string, args ...any) *Step {
+		return because(newStep(step, corners[:], ab), format, args...)
+	}
+
+	switch len(floor) {
+	case 3:
+		// Type 1: the fourth square cannot be a or b.
+		step := urStep(1)
+		g.eliminate(step, roof, ab)
+		return newURStep(step, "%v already are", squareList(floor))
+
+	case 2:
+		a, b := roof[0], roof[1]
+		side := sees(a, b)
+
+		// Types 2 and 5: the roof squares have the same extra value c, so
+		// one of them holds c.
+		if bits.OnesCount16(extras) == 1 && g.candidates[a] == g.candidates[b] {
+			step := urStep(5)
+			if side {
+				step = urStep(2)
+			}
+			g.eliminate(step, seenByAll(a, b), extras)
+			if step := newURStep(step, "%v or %v holds %v", squareAt(a), squareAt(b), valueMask(extras)); step != nil {
+				return step
+			}
+		}
+
+		if side {
+			for _, u := range sharedUnits(a, b) {
+				// Type 3: the extra values of the roof form a naked subset
+				// with other squares of a unit it lies in.
+				others := without(unitSquares[u][:], roof)
+				for n := 1; n <= 3; n++ {
+					var found *Step
+					combinations(others, n, func(subset []int) bool {
+						values := extras
+						for _, s := range subset {
+							if g.candidates[s] == 0 {
+								return true
+							}
+							values |= g.candidates[s]
+						}
+						if bits.OnesCount16(values) != n+1 {
+							return true
+						}
+
+						step := urStep(3, u)
+						g.eliminate(step, without(others, subset), values)
+						found = because(newStep(step, append(corners[:], subset...), ab),
+							"%v hold one of %v, and with %v they form a naked subset of %v in %v", squareList(roof), valueMask(extras), squareList(subset), valueMask(values), unitAt(u))
+						return found == nil
+					})
+					if found != nil {
+						return found
+					}
+				}
+
+				// Type 4: the roof squares are the only squares of a unit
+				// where a can go, so they cannot be b.
+				for _, value := range maskValues(ab) {
+					if g.onlyIn(u, value, roof) {
+						step := urStep(4, u)
+						g.eliminate(step, roof, ab&^valueBit(value))
+						if step := newURStep(step, "in %v, %d can only go in %v, which cannot both hold %v then",
+							unitAt(u), value, squareList(roof), valueMask(ab&^valueBit(value))); step != nil {
+							return step
+						}
+					}
+				}
+			}
+		} else {
+			// Type 6: a forms an X-Wing on the rectangle, so it goes in the
+			// floor squares, both holding a or both holding b otherwise.
+			for _, value := range maskValues(ab) {
+				for kind := range 2 {
+					lines := []int{squareUnits[a][kind], squareUnits[b][kind]}
+					if g.onlyIn(lines[0], value, cornersIn(corners, kind, lines[0])) &&
+						g.onlyIn(lines[1], value, cornersIn(corners, kind, lines[1])) {
+						step := urStep(6, lines...)
+						g.eliminate(step, roof, valueBit(value))
+						if step := newURStep(step, "in %s, %d can only go in the rectangle, where two opposite squares hold it, and if %v did, %v would both hold the other value",
+							joinUnits(step.Units), value, squareList(roof), squareList(floor)); step != nil {
+							return step
+						}
+					}
+				}
+			}
+		}
+
+	case 1:
+		// Type 5: three squares have the same extra value c, so one of
+		// them holds c.
+		if bits.OnesCount16(extras) == 1 && g.candidates[roof[0]] == g.candidates[roof[1]] && g.candidates[roof[1]] == g.candidates[roof[2]] {
+			step := urStep(5)
+			g.eliminate(step, seenByAll(roof...), extras)
+			return newURStep(step, "one of %v holds %v", squareList(roof), valueMask(extras))
+		}
+	}
+	return nil
+}
+
+// hiddenUniqueRectangle works on a rectangle with a square left with a and b.
+// If the rows and columns of the opposite square only have a as candidate in
+// the rectangle, the opposite square cannot be b: it would force a and b in
+// every square.
+var hiddenUniqueRectangle = technique{name: "Hidden Unique Rectangle", uniqueness: true, find: func(g *grid) *Step {
+	var found *Step
+	g.rectangles(func(corners [4]int, ab uint16) bool {
+		for i, s := range corners {
+			if g.candidates[s] != ab {
+				continue
+			}
+
+			// Corners are in row-major order, so the opposite square is at
+			// the opposite index.
+			opposite := corners[3-i]
+			for _, value := range maskValues(ab) {
+				row, column := squareUnits[opposite][0], squareUnits[opposite][1]
+				if !g.onlyIn(row, value, cornersIn(corners, 0, row)) ||
+					!g.onlyIn(column, value, cornersIn(corners, 1, column)) {
+					continue
+				}
+
+				step := &Step{Units: unitsOf(row, column)}
+				g.eliminate(step, []int{opposite}, ab&^valueBit(value))
+				if found = newStep(step, corners[:], ab); found != nil {
+					found.because(deadlyReason+"%v already is, and in %v and %v, %d can only go in the rectangle, and %v holding %v would force the other values",
+						squareList(corners[:]), valueMask(ab), squareAt(s), unitAt(row), unitAt(column), value, squareAt(opposite), valueMask(ab&^valueBit(value)))
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}}
+
+// bugPlusOne places a value when every unsolved square has two candidates but
+// one, and every value has two candidates in each unit but the extra one. The
+// extra candidate must be placed, or every value could be swapped with the
+// other of each square.
+var bugPlusOne = technique{name: "BUG+1", uniqueness: true, find: func(g *grid) *Step {
+	extra := -1
+	for s, mask := range g.candidates {
+		switch bits.OnesCount16(mask) {
+		case 0, 2:
+		case 3:
+			if extra != -1 {
+				return nil
+			}
+			extra = s
+		default:
+			return nil
+		}
+	}
+	if extra == -1 {
+		return nil
+	}
+
+	// The extra value has three candidates in the units of the square.
+	for _, value := range maskValues(g.candidates[extra]) {
+		if bits.OnesCount16(g.positions(squareUnits[extra][0], value)) != 3 {
+			continue
+		}
+
+		g.candidates[extra] &^= valueBit(value)
+		if !g.isBivalueGrid() {
+			return nil
+		}
+
+		step := &Step{Placements: []Candidate{{Square: squareAt(extra), Value: value}}}
+		return newStep(step, []int{extra}, valueBit(value)).because("Every unsolved square but %v has two candidates, and every value two in each unit but %d in the units of %v; "+
+			"without %d there, the values could be swapped and the solution would not be unique", squareAt(extra), value, squareAt(extra), value)
+	}
+	return nil
+}}
+
+// rectangles calls yield with the corners, in row-major order, of every
+// rectangle of unsolved squares over two rows, two columns and two boxes,
+// and each pair of values candidates of all of them, until yield returns
+// false.
+func (g *grid) rectangles(yield func(corners [4]int, ab uint16) bool) {
+	for r1 := range numRows {
+		for r2 := r1 + 1; r2 < numRows; r2++ {
+			for c1 := range numColumns {
+				for c2 := c1 + 1; c2 < numColumns; c2++ {
+					if (r1/boxSize == r2/boxSize) == (c1/boxSize == c2/boxSize) {
+						continue
+					}
+
+					corners := [4]int{squareIndex(r1, c1), squareIndex(r1, c2), squareIndex(r2, c1), squareIndex(r2, c2)}
+					common := allValues
+					for _, s := range corners {
+						common &= g.candidates[s]
+					}
+					for _, pair := range combinationsOf(maskValues(common), 2) {
+						if !yield(corners, valueBit(pair[0])|valueBit(pair[1])) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// onlyIn reports whether the squares having a value as candidate in a unit
+// are exactly the given ones.
+func (g *grid) onlyIn(unit, value int, squares []int) bool {
+	return slices.Equal(unitSquaresWith(g, unit, value), squares)
+}
+
+// cornersIn returns the corners of a rectangle in a unit of the given kind.
+func cornersIn(corners [4]int, kind, unit int) []int {
+	var result []int
+	for _, s := range corners {
+		if squareUnits[s][kind] == unit {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// sharedUnits returns the units two squares both lie in.
+func sharedUnits(a, b int) []int {
+	var units []int
+	for kind := range 3 {
+		if squareUnits[a][kind] == squareUnits[b][kind] {
+			units = append(units, squareUnits[a][kind])
+		}
+	}
+	return units
+}
+
+// combinationsOf returns every subset of size k of the items.
+func combinationsOf(items []int, k int) [][]int {
+	var subsets [][]int
+	combinations(items, k, func(subset []int) bool {
+		subsets = append(subsets, slices.Clone(subset))
+		return true
+	})
+	return subsets
+}
+
+// isBivalueGrid reports whether every value has zero or two candidates in
+// each unit.
+func (g *grid) isBivalueGrid() bool {
+	for u := range numUnits {
+		for value := 1; value <= numDigits; value++ {
+			if count := bits.OnesCount16(g.positions(u, value)); count != 0 && count != 2 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package sudoku_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueness(t *testing.T) {
+	square := func(row, column int) sudoku.Square { return sudoku.Square{Row: row, Column: column} }
+
+	t.Run("unique rectangle type 1", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			square(0, 0): {1, 2}, square(0, 1): {1, 2},
+			square(3, 0): {1, 2}, square(3, 1): {1, 2, 3, 4},
+		})
+
+		step := findStep(t, "Unique Rectangle", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Unique Rectangle Type 1", step.Technique)
+		assert.Equal(t, []sudoku.Square{square(0, 0), square(0, 1), square(3, 0), square(3, 1)}, step.Squares)
+		assert.Equal(t, []int{1, 2}, step.Values)
+		assert.Equal(t, []sudoku.Candidate{
+			{Square: square(3, 1), Value: 1},
+			{Square: square(3, 1), Value: 2},
+		}, step.Eliminations)
+	})
+
+	t.Run("unique rectangle type 5", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			square(0, 0): {1, 2}, square(0, 1): {1, 2, 3},
+			square(3, 0): {1, 2, 3}, square(3, 1): {1, 2},
+		})
+
+		step := findStep(t, "Unique Rectangle", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Unique Rectangle Type 5", step.Technique)
+		assert.Equal(t, []sudoku.Candidate{
+			{Square: square(1, 0), Value: 3},
+			{Square: square(2, 0), Value: 3},
+			{Square: square(4, 1), Value: 3},
+			{Square: square(5, 1), Value: 3},
+		}, step.Eliminations)
+	})
+
+	t.Run("unique rectangle type 3", func(t *testing.T) {
+		board := candidateBoard(t, map[sudoku.Square][]int{
+			square(0, 0): {1, 2, 3}, square(0, 1): {1, 2, 4}, square(0, 2): {3, 4},
+			square(3, 0): {1, 2}, square(3, 1): {1, 2},
+		})
+
+		step := findStep(t, "Unique Rectangle", board)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Unique Rectangle Type 3", step.Technique)
+		assert.Equal(t, []int{1, 2}, step.Values)
+		assert.True(t, strings.HasPrefix(step.Explanation, "The rectangle r1c1, r1c2, r4c1, r4c2 must not be left with only 1, 2, "+
+			"which could be swapped: r1c1, r1c2 hold one of 3, 4, and with r1c3 they form a naked subset of 3, 4 in "), step.Explanation)
+	})
+
+	t.Run("bug+1", func(t *testing.T) {
+		// Every square has the values of two different solutions as
+		// candidates, and r1c1 an extra one.
+		board, err := sudoku.NewBoard(easyProblems[0])
+		assert.NoError(t, err)
+		solution := sudoku.Solver(board)
+
+		candidates := map[sudoku.Square][]int{}
+		for row := range 9 {
+			for column := range 9 {
+				value, err := solution.GetValue(row, column)
+				assert.NoError(t, err)
+				candidates[square(row, column)] = []int{value, value%9 + 1}
+			}
+		}
+		first := candidates[square(0, 0)][0]
+		extra := (first+1)%9 + 1
+		candidates[square(0, 0)] = append(candidates[square(0, 0)], extra)
+
+		step := findStep(t, "BUG+1", candidateBoard(t, candidates))
+		assert.NotNil(t, step)
+		assert.Equal(t, []sudoku.Candidate{{Square: square(0, 0), Value: extra}}, step.Placements)
+		assert.Empty(t, step.Eliminations)
+		assert.Equal(t, fmt.Sprintf("Every unsolved square but r1c1 has two candidates, and every value two in each unit but %d in the units of r1c1; "+
+			"without %d there, the values could be swapped and the solution would not be unique, so r1c1 is %d.", extra, extra, extra), step.Explanation)
+	})
+
+	t.Run("disable uniqueness techniques", func(t *testing.T) {
+		var names []string
+		for _, technique := range sudoku.DefaultTechniques() {
+			if sudoku.AssumesUniqueness(technique) {
+				names = append(names, technique.Name())
+			}
+		}
+		assert.Equal(t, []string{"Unique Rectangle", "BUG+1", "Hidden Unique Rectangle"}, names)
+
+		solver := sudoku.NewLogicalSolver()
+		solver.NoUniqueness = true
+		for _, boardString := range hardProblems {
+			board, err := sudoku.NewBoard(boardString)
+			assert.NoError(t, err)
+
+			_, steps, _ := solver.Solve(board)
+			for _, step := range steps {
+				assert.False(t, strings.Contains(step.Technique, "Unique") || step.Technique == "BUG+1", step.Technique)
+			}
+		}
+	})
+}
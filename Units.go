@@ -0,0 +1,190 @@
+package sudoku
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	numUnits = 3 * numDigits
+	numPeers = 20
+	boxSize  = 3
+)
+
+// Square identifies a square of the grid by its row and column.
+type Square struct {
+	Row    int `json:"row"`
+	Column int `json:"column"`
+}
+
+// String returns the square in the usual r1c1 notation, counting from 1.
+func (s Square) String() string {
+	return fmt.Sprintf("r%dc%d", s.Row+1, s.Column+1)
+}
+
+// UnitKind is the kind of a unit: a row, a column or a box.
+type UnitKind int
+
+const (
+	RowUnit UnitKind = iota
+	ColumnUnit
+	BoxUnit
+)
+
+func (k UnitKind) String() string {
+	switch k {
+	case RowUnit:
+		return "row"
+	case ColumnUnit:
+		return "column"
+	case BoxUnit:
+		return "box"
+	}
+	return fmt.Sprintf("UnitKind(%d)", int(k))
+}
+
+// MarshalText encodes the kind as its name, as in JSON.
+func (k UnitKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *UnitKind) UnmarshalText(text []byte) error {
+	for _, kind := range []UnitKind{RowUnit, ColumnUnit, BoxUnit} {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown unit kind %q", text)
+}
+
+// Unit is a group of nine squares that must hold every value exactly once.
+// Boxes are numbered from left to right, then top to bottom. In JSON, a unit
+// is its kind and index only: its squares follow from them when decoding.
+type Unit struct {
+	Kind    UnitKind          `json:"kind"`
+	Index   int               `json:"index"`
+	Squares [numDigits]Square `json:"-"`
+}
+
+// UnmarshalJSON decodes a unit from its kind and index, and fills in its
+// squares.
+func (u *Unit) UnmarshalJSON(data []byte) error {
+	var unit struct {
+		Kind  UnitKind `json:"kind"`
+		Index int      `json:"index"`
+	}
+	if err := json.Unmarshal(data, &unit); err != nil {
+		return err
+	}
+	if unit.Index < 0 || unit.Index >= numDigits {
+		return fmt.Errorf("invalid %v index %d", unit.Kind, unit.Index)
+	}
+
+	*u = unitAt(int(unit.Kind)*numDigits + unit.Index)
+	return nil
+}
+
+// String returns the unit's kind and index, counting from 1.
+func (u Unit) String() string {
+	return fmt.Sprintf("%s %d", u.Kind, u.Index+1)
+}
+
+var (
+	// unitSquares holds the squares of each unit: rows first, then columns,
+	// then boxes.
+	unitSquares [numUnits][numDigits]int
+
+	// squareUnits holds the row, column and box units of each square.
+	squareUnits [numSquares][3]int
+
+	// peerSquares holds the squares sharing a unit with each square, and
+	// peerSets the same squares as a set.
+	peerSquares [numSquares][numPeers]int
+	peerSets    [numSquares]squareSet
+)
+
+func init() {
+	for i := range numDigits {
+		for j := range numDigits {
+			boxRow := (i/boxSize)*boxSize + j/boxSize
+			boxColumn := (i%boxSize)*boxSize + j%boxSize
+
+			unitSquares[i][j] = squareIndex(i, j)
+			unitSquares[numDigits+i][j] = squareIndex(j, i)
+			unitSquares[2*numDigits+i][j] = squareIndex(boxRow, boxColumn)
+		}
+	}
+
+	for u, squares := range unitSquares {
+		for _, s := range squares {
+			squareUnits[s][u/numDigits] = u
+		}
+	}
+
+	for s := range numSquares {
+		n := 0
+		for _, u := range squareUnits[s] {
+			for _, p := range unitSquares[u] {
+				if p != s && !slices.Contains(peerSquares[s][:n], p) {
+					peerSquares[s][n] = p
+					peerSets[s].add(p)
+					n++
+				}
+			}
+		}
+	}
+}
+
+func squareIndex(row, column int) int {
+	return row*numColumns + column
+}
+
+func squareAt(index int) Square {
+	return Square{Row: index / numColumns, Column: index % numColumns}
+}
+
+func unitAt(index int) Unit {
+	unit := Unit{Kind: UnitKind(index / numDigits), Index: index % numDigits}
+	for i, s := range unitSquares[index] {
+		unit.Squares[i] = squareAt(s)
+	}
+	return unit
+}
+
+// Units returns the 27 units of the grid: the rows, then the columns, then the
+// boxes.
+func Units() []Unit {
+	units := make([]Unit, numUnits)
+	for i := range units {
+		units[i] = unitAt(i)
+	}
+	return units
+}
+
+// UnitsOf returns the row, column and box containing a square.
+func UnitsOf(row, column int) ([]Unit, error) {
+	if !isValidPosition(row, column) {
+		return nil, ErrInvalidPosition
+	}
+
+	units := make([]Unit, 0, len(squareUnits[0]))
+	for _, u := range squareUnits[squareIndex(row, column)] {
+		units = append(units, unitAt(u))
+	}
+	return units, nil
+}
+
+// PeersOf returns the 20 squares sharing a unit with a square.
+func PeersOf(row, column int) ([]Square, error) {
+	if !isValidPosition(row, column) {
+		return nil, ErrInvalidPosition
+	}
+
+	peers := make([]Square, 0, numPeers)
+	for _, p := range peerSquares[squareIndex(row, column)] {
+		peers = append(peers, squareAt(p))
+	}
+	return peers, nil
+}
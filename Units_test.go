@@ -0,0 +1,82 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnits(t *testing.T) {
+	t.Run("every unit holds nine distinct squares", func(t *testing.T) {
+		units := sudoku.Units()
+		assert.Len(t, units, 27)
+
+		counts := make(map[sudoku.Square]int)
+		for _, unit := range units {
+			seen := make(map[sudoku.Square]struct{})
+			for _, square := range unit.Squares {
+				seen[square] = struct{}{}
+				counts[square]++
+			}
+			assert.Len(t, seen, 9, unit.String())
+		}
+
+		// Each square belongs to exactly one row, one column and one box.
+		assert.Len(t, counts, 81)
+		for square, count := range counts {
+			assert.Equal(t, 3, count, square.String())
+		}
+	})
+
+	t.Run("units are ordered rows, columns, boxes", func(t *testing.T) {
+		units := sudoku.Units()
+
+		assert.Equal(t, sudoku.RowUnit, units[2].Kind)
+		assert.Equal(t, 2, units[2].Index)
+		assert.Equal(t, sudoku.Square{Row: 2, Column: 8}, units[2].Squares[8])
+
+		assert.Equal(t, sudoku.ColumnUnit, units[13].Kind)
+		assert.Equal(t, 4, units[13].Index)
+		assert.Equal(t, sudoku.Square{Row: 8, Column: 4}, units[13].Squares[8])
+
+		assert.Equal(t, sudoku.BoxUnit, units[23].Kind)
+		assert.Equal(t, 5, units[23].Index)
+		assert.Equal(t, sudoku.Square{Row: 3, Column: 6}, units[23].Squares[0])
+		assert.Equal(t, sudoku.Square{Row: 5, Column: 8}, units[23].Squares[8])
+		assert.Equal(t, "box 6", units[23].String())
+	})
+
+	t.Run("units of a square", func(t *testing.T) {
+		units, err := sudoku.UnitsOf(4, 7)
+		assert.NoError(t, err)
+		assert.Len(t, units, 3)
+
+		assert.Equal(t, "row 5", units[0].String())
+		assert.Equal(t, "column 8", units[1].String())
+		assert.Equal(t, "box 6", units[2].String())
+
+		_, err = sudoku.UnitsOf(9, 0)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidPosition)
+	})
+
+	t.Run("peers of a square", func(t *testing.T) {
+		peers, err := sudoku.PeersOf(0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, peers, 20)
+
+		assert.NotContains(t, peers, sudoku.Square{Row: 0, Column: 0})
+		assert.Contains(t, peers, sudoku.Square{Row: 0, Column: 8})
+		assert.Contains(t, peers, sudoku.Square{Row: 8, Column: 0})
+		assert.Contains(t, peers, sudoku.Square{Row: 2, Column: 2})
+		assert.NotContains(t, peers, sudoku.Square{Row: 3, Column: 3})
+
+		_, err = sudoku.PeersOf(0, -1)
+		assert.ErrorIs(t, err, sudoku.ErrInvalidPosition)
+	})
+
+	t.Run("square notation", func(t *testing.T) {
+		assert.Equal(t, "r1c1", sudoku.Square{}.String())
+		assert.Equal(t, "r9c4", sudoku.Square{Row: 8, Column: 3}.String())
+	})
+}
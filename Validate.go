@@ -0,0 +1,200 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrIncompleteSolution = fmt.Errorf("solution has empty squares")
+	ErrGivenMismatch      = fmt.Errorf("solution does not keep a given")
+)
+
+// ConflictKind is the kind of problem reported by Validate.
+type ConflictKind int
+
+const (
+	// ConflictDuplicate is a value appearing more than once in a unit.
+	ConflictDuplicate ConflictKind = iota
+	// ConflictNoCandidates is an empty square whose peers already hold every
+	// value.
+	ConflictNoCandidates
+	// ConflictInvalidString is a board string that does not hold 81 squares.
+	ConflictInvalidString
+)
+
+func (k ConflictKind) String() string {
+	switch k {
+	case ConflictDuplicate:
+		return "duplicate"
+	case ConflictNoCandidates:
+		return "no candidates"
+	case ConflictInvalidString:
+		return "invalid board string"
+	}
+	return fmt.Sprintf("ConflictKind(%d)", int(k))
+}
+
+// Conflict is a problem found in a board string by Validate.
+type Conflict struct {
+	Kind ConflictKind
+	// Value is the duplicated value, for ConflictDuplicate.
+	Value int
+	// Unit is the unit holding the duplicates, for ConflictDuplicate.
+	Unit *Unit
+	// Squares are the squares holding the duplicates, or the square left
+	// without candidates.
+	Squares []Square
+	// Found is the number of squares read, for ConflictInvalidString.
+	Found int
+}
+
+func (c Conflict) String() string {
+	squares := make([]string, len(c.Squares))
+	for i, s := range c.Squares {
+		squares[i] = s.String()
+	}
+
+	switch c.Kind {
+	case ConflictDuplicate:
+		return fmt.Sprintf("%d appears more than once in %v at %s", c.Value, c.Unit, strings.Join(squares, ", "))
+	case ConflictInvalidString:
+		return fmt.Sprintf("%v: found %d squares, expected %d", c.Kind, c.Found, numSquares)
+	}
+	return fmt.Sprintf("%v at %s", c.Kind, strings.Join(squares, ", "))
+}
+
+// Validate checks a board string without stopping at the first problem, unlike
+// NewBoard. It returns every value duplicated in a row, column or box, then
+// every empty square whose peers hold all nine values. Only the values in the
+// string are considered, without constraint propagation. A string that cannot
+// be read at all is reported as a single ConflictInvalidString.
+func Validate(str string) []Conflict {
+	values, err := parseBoardString(str)
+	if err != nil {
+		conflict := Conflict{Kind: ConflictInvalidString}
+		var boardErr *BoardError
+		if errors.As(err, &boardErr) {
+			conflict.Found = boardErr.Found
+		}
+		return []Conflict{conflict}
+	}
+
+	var conflicts []Conflict
+	for u, squares := range unitSquares {
+		var seen [numDigits + 1][]Square
+		for _, s := range squares {
+			if value := values[s]; value != EmptySquare {
+				seen[value] = append(seen[value], squareAt(s))
+			}
+		}
+
+		for value, squares := range seen {
+			if len(squares) > 1 {
+				unit := unitAt(u)
+				conflicts = append(conflicts, Conflict{
+					Kind:    ConflictDuplicate,
+					Value:   value,
+					Unit:    &unit,
+					Squares: squares,
+				})
+			}
+		}
+	}
+
+	for s, value := range values {
+		if value != EmptySquare {
+			continue
+		}
+
+		possible := allValues
+		for _, p := range peerSquares[s] {
+			if values[p] != EmptySquare {
+				possible &^= valueBit(values[p])
+			}
+		}
+		if possible == 0 {
+			conflicts = append(conflicts, Conflict{
+				Kind:    ConflictNoCandidates,
+				Squares: []Square{squareAt(s)},
+			})
+		}
+	}
+	return conflicts
+}
+
+// IsComplete reports whether every square holds a value.
+func (b *Board) IsComplete() bool {
+	for i := range numSquares {
+		if b.value(i) == EmptySquare {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValid reports whether the board follows the rules of sudoku so far: no two
+// peers hold the same value and every square has a possible value. A valid
+// board may still have no solution.
+func (b *Board) IsValid() bool {
+	return b.invalidSquare() == nil
+}
+
+// IsSolved reports whether every square holds a value without breaking the
+// rules of sudoku.
+func (b *Board) IsSolved() bool {
+	return b.IsComplete() && b.IsValid()
+}
+
+// invalidSquare describes the first square breaking the rules of sudoku, or
+// returns nil if there is none.
+func (b *Board) invalidSquare() *BoardError {
+	for i, mask := range b.squares {
+		if mask == 0 {
+			s := squareAt(i)
+			return &BoardError{Err: ErrContradiction, Row: s.Row, Column: s.Column}
+		}
+
+		value := b.value(i)
+		if value == EmptySquare {
+			continue
+		}
+		for _, u := range squareUnits[i] {
+			for _, p := range unitSquares[u] {
+				if p > i && b.value(p) == value {
+					// Report the later square, as NewBoard would.
+					err := valueError(ErrDuplicateValue, p, value)
+					peer := squareAt(i)
+					err.Peer, err.Unit = &peer, UnitKind(u/numDigits)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// VerifySolution checks that solution is a solved board keeping every given
+// of puzzle. It returns a *BoardError locating the first problem, wrapping
+// ErrIncompleteSolution for an empty square, ErrDuplicateValue for a value
+// repeated in a unit, or ErrGivenMismatch for a given replaced by another
+// value.
+func VerifySolution(puzzle, solution *Board) error {
+	for i := range numSquares {
+		if solution.value(i) == EmptySquare {
+			return valueError(ErrIncompleteSolution, i, EmptySquare)
+		}
+	}
+
+	if err := solution.invalidSquare(); err != nil {
+		return err
+	}
+
+	for i, given := range puzzle.givens {
+		if given != EmptySquare && solution.value(i) != int(given) {
+			return valueError(ErrGivenMismatch, i, solution.value(i))
+		}
+	}
+	return nil
+}
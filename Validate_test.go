@@ -0,0 +1,145 @@
+package sudoku_test
+
+import (
+	"testing"
+
+	"github.com/kroosec/sudoku-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid boards have no conflicts", func(t *testing.T) {
+		for _, boardString := range append([]string{"", noSolutionProblem}, hardProblems[:10]...) {
+			assert.Empty(t, sudoku.Validate(boardString))
+		}
+	})
+
+	t.Run("invalid board string", func(t *testing.T) {
+		conflicts := sudoku.Validate("1234567")
+		assert.Equal(t, []sudoku.Conflict{{Kind: sudoku.ConflictInvalidString, Found: 7}}, conflicts)
+		assert.Equal(t, "invalid board string: found 7 squares, expected 81", conflicts[0].String())
+	})
+
+	t.Run("report every conflict", func(t *testing.T) {
+		boardString := `
+3 3 . |. . . |. . .
+. . . |2 4 9 |. . 5
+3 . . |. . . |. . .
+------+------+------
+. 1 2 |. . . |. . .
+. . . |. . . |. . .
+. . . |. . . |. . .
+------+------+------
+. 7 . |. . . |. . .
+. 8 . |. . . |. . .
+4 6 . |. . . |. . 9
+`
+		conflicts := sudoku.Validate(boardString)
+
+		var got []string
+		for _, c := range conflicts {
+			got = append(got, c.String())
+		}
+		assert.Equal(t, []string{
+			"3 appears more than once in row 1 at r1c1, r1c2",
+			"3 appears more than once in column 1 at r1c1, r3c1",
+			"3 appears more than once in box 1 at r1c1, r1c2, r3c1",
+			"no candidates at r2c2",
+		}, got)
+
+		assert.Equal(t, sudoku.ConflictDuplicate, conflicts[2].Kind)
+		assert.Equal(t, 3, conflicts[2].Value)
+		assert.Equal(t, sudoku.BoxUnit, conflicts[2].Unit.Kind)
+		assert.Equal(t, sudoku.ConflictNoCandidates, conflicts[3].Kind)
+		assert.Nil(t, conflicts[3].Unit)
+		assert.Equal(t, []sudoku.Square{{Row: 1, Column: 1}}, conflicts[3].Squares)
+	})
+}
+
+func TestBoardPredicates(t *testing.T) {
+	t.Run("puzzles are valid but not complete", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		assert.False(t, board.IsComplete())
+		assert.True(t, board.IsValid())
+		assert.False(t, board.IsSolved())
+	})
+
+	t.Run("solutions are solved", func(t *testing.T) {
+		board, err := sudoku.NewBoard(hardProblems[0])
+		assert.NoError(t, err)
+
+		solved := sudoku.Solver(board)
+		assert.True(t, solved.IsComplete())
+		assert.True(t, solved.IsValid())
+		assert.True(t, solved.IsSolved())
+	})
+
+	t.Run("raw boards may break the rules", func(t *testing.T) {
+		board, err := sudoku.NewRawBoard("33...............................................................................")
+		assert.NoError(t, err)
+		assert.False(t, board.IsValid())
+
+		board, err = sudoku.NewRawBoard(sudoku.Solver(mustBoard(t, hardProblems[0])).String())
+		assert.NoError(t, err)
+		assert.True(t, board.IsSolved())
+	})
+
+	t.Run("contradictory boards are not valid", func(t *testing.T) {
+		assert.False(t, contradictoryBoard(t).IsValid())
+	})
+}
+
+func TestVerifySolution(t *testing.T) {
+	puzzle := mustBoard(t, hardProblems[0])
+	solution := sudoku.Solver(puzzle).String()
+
+	t.Run("valid solution", func(t *testing.T) {
+		assert.NoError(t, sudoku.VerifySolution(puzzle, sudoku.Solver(puzzle)))
+
+		board, err := sudoku.NewRawBoard(solution)
+		assert.NoError(t, err)
+		assert.NoError(t, sudoku.VerifySolution(puzzle, board))
+	})
+
+	t.Run("invalid solutions", func(t *testing.T) {
+		type testCase struct {
+			name     string
+			solution string
+			err      error
+			message  string
+		}
+
+		// Swapping two rows of a solution keeps it valid, but moves givens.
+		swapped := solution[9:18] + solution[:9] + solution[18:]
+
+		cases := []testCase{
+			{"Incomplete", "." + solution[1:], sudoku.ErrIncompleteSolution,
+				"solution has empty squares: r1c1"},
+			{"Duplicate", solution[:80] + solution[79:80], sudoku.ErrDuplicateValue,
+				"value already exists in unit: 9 at r9c9 is already in the same column at r4c9"},
+			{"Given changed", swapped, sudoku.ErrGivenMismatch,
+				"solution does not keep a given: 6 at r1c1"},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				board, err := sudoku.NewRawBoard(c.solution)
+				assert.NoError(t, err)
+
+				err = sudoku.VerifySolution(puzzle, board)
+				assert.ErrorIs(t, err, c.err)
+				assert.EqualError(t, err, c.message)
+			})
+		}
+	})
+}
+
+func mustBoard(t *testing.T, boardString string) *sudoku.Board {
+	t.Helper()
+
+	board, err := sudoku.NewBoard(boardString)
+	assert.NoError(t, err)
+	return board
+}
@@ -1,16 +1,37 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/kroosec/sudoku-go"
 )
 
 func main() {
+	engineName := flag.String("engine", sudoku.DefaultEngine.Name(),
+		"solver engine, one of: "+strings.Join(sudoku.EngineNames(), ", "))
+	compare := flag.Bool("compare", false, "solve with every engine and report how long each took")
+	trace := flag.String("trace", "", "solve like a human and print every step, as text or json")
+	flag.Parse()
+
 	boardString := "4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......"
-	if len(os.Args) > 1 {
-		boardString = os.Args[1]
+	if flag.NArg() > 0 {
+		boardString = flag.Arg(0)
+	}
+
+	engine, err := sudoku.EngineByName(*engineName)
+	if err != nil {
+		fmt.Printf("Error selecting engine: %v\n", err)
+		os.Exit(1)
+	}
+	if *trace != "" && *trace != "text" && *trace != "json" {
+		fmt.Printf("Error selecting trace format: unknown format %q, expected text or json\n", *trace)
+		os.Exit(1)
 	}
 
 	board, err := sudoku.NewBoard(boardString)
@@ -19,14 +40,67 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *trace != "" {
+		printTrace(board, *trace)
+		return
+	}
+
 	fmt.Println("Unsolved board:")
 	fmt.Println(board)
 
-	solvedBoard := sudoku.Solver(board)
-	if solvedBoard != nil {
+	if *compare {
+		compareEngines(board)
+		return
+	}
+
+	solvedBoard, err := engine.Solve(context.Background(), board)
+	if err == nil {
 		fmt.Println("\nSolved board:")
 		fmt.Println(solvedBoard)
 	} else {
-		fmt.Println("\nCould not solve the board.")
+		fmt.Printf("\nCould not solve the board: %v\n", err)
+	}
+}
+
+func printTrace(board *sudoku.Board, format string) {
+	trace, err := sudoku.NewLogicalSolver().Trace(board)
+	if trace == nil {
+		fmt.Printf("Could not solve the board: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(trace); err != nil {
+			fmt.Printf("Error writing trace: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Print(trace)
+	if err != nil {
+		fmt.Printf("\nCould not solve the board: %v\n", err)
+	}
+}
+
+func compareEngines(board *sudoku.Board) {
+	fmt.Println()
+	for _, name := range sudoku.EngineNames() {
+		engine, err := sudoku.EngineByName(name)
+		if err != nil {
+			fmt.Printf("%-14s error: %v\n", name, err)
+			continue
+		}
+
+		start := time.Now()
+		solvedBoard, err := engine.Solve(context.Background(), board)
+		elapsed := time.Since(start)
+		if err != nil {
+			fmt.Printf("%-14s %12v  error: %v\n", name, elapsed, err)
+			continue
+		}
+		fmt.Printf("%-14s %12v  %s\n", name, elapsed, solvedBoard)
 	}
 }